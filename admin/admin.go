@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Interface describes the worker engines that can be administered at runtime,
+// e.g. *parallel.Worker, *sequence.Worker and *combined.Worker.
+type Interface interface {
+	// Pause disables all worker handlers of the given name until they are
+	// resumed again.
+	Pause(nam string) error
+
+	// Resume enables all paused worker handlers of the given name again.
+	Resume(nam string) error
+
+	// Status returns the runtime snapshot of all managed worker handlers.
+	Status() []status.Status
+
+	// Trigger executes all worker handlers of the given name right away.
+	Trigger(nam string) error
+}
+
+type Config struct {
+	// Log is a standard logger interface to forward structured log messages to
+	// any output interface e.g. stdout.
+	Log logger.Interface
+
+	// Wor is the worker engine administered via HTTP.
+	Wor Interface
+}
+
+// Admin is a JSON HTTP handler that allows operators to inspect and control
+// the worker handlers of a worker engine at runtime. Admin serves the runtime
+// snapshot of all worker handlers on GET requests, and pauses, resumes or
+// triggers the worker handlers of a given name on POST requests to the paths
+// ending with "/{name}/pause", "/{name}/resume" and "/{name}/trigger". Admin
+// may be mounted on any path prefix, e.g. via gorilla/mux.
+type Admin struct {
+	log logger.Interface
+	wor Interface
+}
+
+func New(c Config) *Admin {
+	if c.Log == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
+	}
+	if c.Wor == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Wor must not be empty", c)))
+	}
+
+	return &Admin{
+		log: c.Log,
+		wor: c.Wor,
+	}
+}
@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Resource is the runtime snapshot of a single worker handler as served by the
+// admin endpoint, e.g. for clients like the status command of *runner.Runner.
+type Resource struct {
+	Active   bool   `json:"active"`
+	Cooler   string `json:"cooler"`
+	Duration string `json:"duration"`
+	Engine   string `json:"engine"`
+	Error    string `json:"error,omitempty"`
+	Index    int    `json:"index"`
+	Name     string `json:"name"`
+	Paused   bool   `json:"paused"`
+	Stage    int    `json:"stage"`
+	State    string `json:"state"`
+}
+
+// ServeHTTP serves the runtime snapshot of all worker handlers as JSON, or
+// applies the requested operation to the worker handlers of the given name.
+// Unknown worker handlers result in 404.
+func (a *Admin) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var seg []string
+	{
+		seg = strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	}
+
+	var ope func(string) error
+	if len(seg) >= 2 {
+		switch seg[len(seg)-1] {
+		case "pause":
+			ope = a.wor.Pause
+		case "resume":
+			ope = a.wor.Resume
+		case "trigger":
+			ope = a.wor.Trigger
+		}
+	}
+
+	if ope == nil {
+		if r.Method != http.MethodGet {
+			write(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+
+		write(w, http.StatusOK, a.status())
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		write(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+
+	var nam string
+	{
+		nam = seg[len(seg)-2]
+	}
+
+	err := ope(nam)
+	if status.IsNotFound(err) {
+		write(w, http.StatusNotFound, map[string]string{"error": "handler not found"})
+		return
+	} else if status.IsDaemonDisabled(err) {
+		write(w, http.StatusConflict, map[string]string{"error": "daemon disabled"})
+		return
+	} else if err != nil {
+		a.log.Log(
+			"level", "error",
+			"message", "worker administration failed",
+			"stack", tracer.Json(tracer.Mask(err)),
+		)
+
+		write(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	write(w, http.StatusOK, a.status())
+}
+
+func (a *Admin) status() []Resource {
+	res := []Resource{}
+
+	for _, x := range a.wor.Status() {
+		var err string
+		if x.Error != nil {
+			err = x.Error.Error()
+		}
+
+		res = append(res, Resource{
+			Active:   x.Active,
+			Cooler:   x.Cooler.String(),
+			Duration: x.Duration.String(),
+			Engine:   x.Engine,
+			Error:    err,
+			Index:    x.Index,
+			Name:     x.Name,
+			Paused:   x.Paused,
+			Stage:    x.Stage,
+			State:    x.State,
+		})
+	}
+
+	return res
+}
+
+func write(w http.ResponseWriter, cod int, res any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(cod)
+
+	_ = json.NewEncoder(w).Encode(res)
+}
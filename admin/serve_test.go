@@ -0,0 +1,209 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+func Test_Admin_ServeHTTP(t *testing.T) {
+	testCases := []struct {
+		met string
+		pat string
+		cod int
+		ope []string
+	}{
+		// Case 000, status snapshot
+		{
+			met: http.MethodGet,
+			pat: "/admin",
+			cod: http.StatusOK,
+			ope: nil,
+		},
+		// Case 001, pause handler
+		{
+			met: http.MethodPost,
+			pat: "/admin/foo/pause",
+			cod: http.StatusOK,
+			ope: []string{"pause foo"},
+		},
+		// Case 002, resume handler
+		{
+			met: http.MethodPost,
+			pat: "/admin/foo/resume",
+			cod: http.StatusOK,
+			ope: []string{"resume foo"},
+		},
+		// Case 003, trigger handler
+		{
+			met: http.MethodPost,
+			pat: "/admin/foo/trigger",
+			cod: http.StatusOK,
+			ope: []string{"trigger foo"},
+		},
+		// Case 004, unknown handler
+		{
+			met: http.MethodPost,
+			pat: "/admin/bar/trigger",
+			cod: http.StatusNotFound,
+			ope: []string{"trigger bar"},
+		},
+		// Case 005, operations require POST
+		{
+			met: http.MethodGet,
+			pat: "/admin/foo/trigger",
+			cod: http.StatusMethodNotAllowed,
+			ope: nil,
+		},
+		// Case 006, status snapshot requires GET
+		{
+			met: http.MethodPost,
+			pat: "/admin",
+			cod: http.StatusMethodNotAllowed,
+			ope: nil,
+		},
+		// Case 007, failing operation
+		{
+			met: http.MethodPost,
+			pat: "/admin/err/pause",
+			cod: http.StatusInternalServerError,
+			ope: []string{"pause err"},
+		},
+		// Case 008, trigger handler of disabled daemon
+		{
+			met: http.MethodPost,
+			pat: "/admin/dis/trigger",
+			cod: http.StatusConflict,
+			ope: []string{"trigger dis"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var wor *testWorker
+			{
+				wor = &testWorker{}
+			}
+
+			var srv *httptest.Server
+			{
+				rtr := mux.NewRouter()
+				rtr.PathPrefix("/admin").Handler(New(Config{Log: logger.Fake(), Wor: wor}))
+				srv = httptest.NewServer(rtr)
+			}
+
+			{
+				defer srv.Close()
+			}
+
+			var req *http.Request
+			{
+				req, _ = http.NewRequest(tc.met, srv.URL+tc.pat, nil)
+			}
+
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			{
+				defer res.Body.Close()
+			}
+
+			if res.StatusCode != tc.cod {
+				t.Fatal("expected", tc.cod, "got", res.StatusCode)
+			}
+
+			if dif := cmp.Diff(tc.ope, wor.ope); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if tc.cod != http.StatusOK {
+				return
+			}
+
+			var bod []Resource
+			{
+				err := json.NewDecoder(res.Body).Decode(&bod)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			exp := []Resource{
+				{
+					Active:   true,
+					Cooler:   "1m0s",
+					Duration: "2s",
+					Engine:   "parallel",
+					Error:    "test error",
+					Index:    0,
+					Name:     "foo",
+					Paused:   false,
+					Stage:    0,
+					State:    status.StateCooling,
+				},
+			}
+
+			if dif := cmp.Diff(exp, bod); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+type testWorker struct {
+	ope []string
+}
+
+func (w *testWorker) Pause(nam string) error {
+	return w.operate("pause", nam)
+}
+
+func (w *testWorker) Resume(nam string) error {
+	return w.operate("resume", nam)
+}
+
+func (w *testWorker) Status() []status.Status {
+	return []status.Status{
+		{
+			Active:   true,
+			Cooler:   time.Minute,
+			Duration: 2 * time.Second,
+			Engine:   "parallel",
+			Error:    errors.New("test error"),
+			Name:     "foo",
+			State:    status.StateCooling,
+		},
+	}
+}
+
+func (w *testWorker) Trigger(nam string) error {
+	return w.operate("trigger", nam)
+}
+
+func (w *testWorker) operate(ope string, nam string) error {
+	w.ope = append(w.ope, ope+" "+nam)
+
+	if nam == "err" {
+		return tracer.Mask(errors.New("test error"))
+	}
+	if nam == "dis" {
+		return tracer.Mask(status.DaemonDisabledError)
+	}
+	if nam != "foo" {
+		return tracer.Mask(status.NotFoundError)
+	}
+
+	return nil
+}
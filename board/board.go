@@ -0,0 +1,39 @@
+package board
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey struct{}
+
+// Board is a typed scratchpad scoped to a single graph execution of the
+// *sequence.Worker engine. Worker handlers read and write values via Get and
+// Set, using the context provided to handler.EnsureContext. Values written by
+// any node are visible to all of its dependents. Board is safe for concurrent
+// use by the nodes of the same graph execution.
+type Board struct {
+	mut sync.RWMutex
+	val map[any]any
+}
+
+func New() *Board {
+	return &Board{
+		val: map[any]any{},
+	}
+}
+
+// With returns a copy of the given context carrying the given board. With may
+// be used to seed a graph execution with initial values, to inspect the values
+// written during a graph execution afterwards, or to test worker handlers in
+// isolation.
+func With(ctx context.Context, brd *Board) context.Context {
+	return context.WithValue(ctx, contextKey{}, brd)
+}
+
+// From returns the board carried by the given context, or nil if the given
+// context does not carry any board.
+func From(ctx context.Context) *Board {
+	brd, _ := ctx.Value(contextKey{}).(*Board)
+	return brd
+}
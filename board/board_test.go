@@ -0,0 +1,107 @@
+package board
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func Test_Board_Get_Set(t *testing.T) {
+	var ctx context.Context
+	{
+		ctx = With(context.Background(), New())
+	}
+
+	var num Key[int]
+	var str Key[string]
+	{
+		num = NewKey[int]("foo")
+		str = NewKey[string]("foo")
+	}
+
+	{
+		_, exi := Get(ctx, num)
+		if exi {
+			t.Fatal("expected", false, "got", exi)
+		}
+	}
+
+	{
+		err := Set(ctx, num, 42)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	// Keys of different types do not collide, even if they share the same name.
+
+	{
+		_, exi := Get(ctx, str)
+		if exi {
+			t.Fatal("expected", false, "got", exi)
+		}
+	}
+
+	{
+		val, exi := Get(ctx, num)
+		if !exi {
+			t.Fatal("expected", true, "got", exi)
+		}
+		if val != 42 {
+			t.Fatal("expected", 42, "got", val)
+		}
+	}
+}
+
+func Test_Board_Set_missing(t *testing.T) {
+	var ctx context.Context
+	{
+		ctx = context.Background()
+	}
+
+	{
+		err := Set(ctx, NewKey[int]("foo"), 42)
+		if !IsMissing(err) {
+			t.Fatal("expected", MissingError, "got", err)
+		}
+	}
+
+	{
+		_, exi := Get(ctx, NewKey[int]("foo"))
+		if exi {
+			t.Fatal("expected", false, "got", exi)
+		}
+	}
+}
+
+func Test_Board_concurrency(t *testing.T) {
+	var ctx context.Context
+	{
+		ctx = With(context.Background(), New())
+	}
+
+	var grp sync.WaitGroup
+	for i := range 100 {
+		grp.Add(1)
+		go func() {
+			defer grp.Done()
+
+			key := NewKey[int](fmt.Sprintf("%d", i%10))
+
+			err := Set(ctx, key, i)
+			if err != nil {
+				t.Error("expected", nil, "got", err)
+			}
+
+			_, exi := Get(ctx, key)
+			if !exi {
+				t.Error("expected", true, "got", exi)
+			}
+		}()
+	}
+
+	{
+		grp.Wait()
+	}
+}
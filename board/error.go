@@ -0,0 +1,19 @@
+package board
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// MissingError is returned by Set if the given context does not carry any
+// board, e.g. because the worker handler is not executed by the
+// *sequence.Worker engine.
+var MissingError = &tracer.Error{
+	Description: "The given context does not carry any board.",
+}
+
+// IsMissing returns true if the given error is or wraps MissingError.
+func IsMissing(err error) bool {
+	return errors.Is(err, MissingError)
+}
@@ -0,0 +1,25 @@
+package board
+
+import "context"
+
+// Get returns the value stored for the given key on the board carried by the
+// given context. Get returns false if the given context does not carry any
+// board, or if no value has been stored for the given key.
+func Get[T any](ctx context.Context, key Key[T]) (T, bool) {
+	var zer T
+
+	brd := From(ctx)
+	if brd == nil {
+		return zer, false
+	}
+
+	brd.mut.RLock()
+	defer brd.mut.RUnlock()
+
+	val, exi := brd.val[key]
+	if !exi {
+		return zer, false
+	}
+
+	return val.(T), true
+}
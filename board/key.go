@@ -0,0 +1,18 @@
+package board
+
+// Key identifies a typed value on a board. Keys of different types never
+// collide, even if they share the same name. Keys are usually declared once as
+// package variables and shared between the producing and the consuming worker
+// handlers.
+type Key[T any] struct {
+	nam string
+}
+
+func NewKey[T any](nam string) Key[T] {
+	return Key[T]{nam: nam}
+}
+
+// String returns the name of the key.
+func (k Key[T]) String() string {
+	return k.nam
+}
@@ -0,0 +1,26 @@
+package board
+
+import (
+	"context"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Set stores the given value for the given key on the board carried by the
+// given context, replacing any value stored for the same key before. Set
+// returns MissingError if the given context does not carry any board.
+func Set[T any](ctx context.Context, key Key[T], val T) error {
+	brd := From(ctx)
+	if brd == nil {
+		return tracer.Mask(MissingError, tracer.Context{Key: "key", Value: key.nam})
+	}
+
+	brd.mut.Lock()
+	defer brd.mut.Unlock()
+
+	{
+		brd.val[key] = val
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Execute runs the given worker handler within its effective timeout, if any.
+// The given name identifies the execution for health reporting.
+// The effective timeout is the handler specific timeout, or the default timeout
+// of the worker engine if the handler does not define its own timeout. Any
+// panic of the given worker handler is converted into handler.PanicError,
+// unless panic recovery got disabled. The start and the end of every execution
+// are recorded for health reporting, where filtered errors do not count as
+// failures.
+func (e *Executor) Execute(ctx context.Context, nam string, han handler.Interface) (err error) {
+
+	defer func() {
+		rec := recover()
+		if rec != nil {
+			err = handler.Recovered(rec)
+		}
+
+		if err != nil && !e.reg.Log(err) {
+			e.hea.Finished(nam, err)
+		} else {
+			e.hea.Finished(nam, nil)
+		}
+
+		// Crash the process deliberately if the user prefers panics over
+		// recovered errors. Note that panics of the underlying handler
+		// implementation may have already been recovered by our internal wrapper
+		// handlers, which is why we panic again with the recovered stack trace.
+
+		if e.pan && handler.IsPanic(err) {
+			panic(tracer.Json(err))
+		}
+	}()
+
+	var tim time.Duration
+	{
+		tim = han.Timeout()
+	}
+
+	if tim == 0 {
+		tim = e.tim
+	}
+
+	{
+		e.hea.Started(nam, tim)
+	}
+
+	if tim > 0 {
+		var can context.CancelFunc
+		{
+			ctx, can = context.WithTimeout(ctx, tim)
+		}
+
+		{
+			defer can()
+		}
+	}
+
+	err = han.EnsureContext(ctx)
+	if handler.IsTimeout(err) {
+		return tracer.Mask(err, tracer.Context{Key: "timeout", Value: tim.String()})
+	} else if err != nil {
+		return tracer.Mask(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Hea is the health tracker recording the start and the end of every worker
+	// handler execution.
+	Hea *health.Health
+
+	// Pan is the optional flag to disable the recovery of panicking worker
+	// handlers. See the Pan option of the worker engines for more information.
+	Pan bool
+
+	// Reg is the registry used to determine whether errors are filtered, so that
+	// filtered errors do not count as failures for health reporting.
+	Reg *registry.Registry
+
+	// Tim is the optional default timeout applied to all worker handler
+	// executions, unless the underlying worker handler implements the
+	// handler.Timeout interface.
+	Tim time.Duration
+}
+
+// Executor runs single worker handler executions on behalf of the worker
+// engines, so that all worker engines apply execution timeouts, panic recovery
+// and health reporting the same way.
+type Executor struct {
+	hea *health.Health
+	pan bool
+	reg *registry.Registry
+	tim time.Duration
+}
+
+func New(c Config) *Executor {
+	if c.Hea == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Hea must not be empty", c)))
+	}
+	if c.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
+	}
+
+	return &Executor{
+		hea: c.Hea,
+		pan: c.Pan,
+		reg: c.Reg,
+		tim: c.Tim,
+	}
+}
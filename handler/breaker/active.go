@@ -0,0 +1,21 @@
+package breaker
+
+// Active returns false while the circuit is open, so that worker engines skip
+// the execution of the wrapped handler implementation. Once the cooldown of the
+// open circuit expired, the circuit half-opens and Active forwards the
+// scheduler primitive of the wrapped handler implementation again, unless the
+// single trial execution of the half-open circuit is already in flight.
+func (b *Breaker) Active() bool {
+	if !b.Interface.Active() {
+		return false
+	}
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	{
+		b.cooled()
+	}
+
+	return b.sta != StateOpen && !b.tri.Load()
+}
@@ -0,0 +1,162 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/handler/proxy"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	MetricState = "worker_handler_breaker_state"
+)
+
+const (
+	StateClosed   = "closed"
+	StateHalfOpen = "half-open"
+	StateOpen     = "open"
+)
+
+type Config struct {
+	// Coo is the optional cooldown duration that an open circuit waits before
+	// allowing a single trial execution in the half-open state. Must not be
+	// negative. Defaults to 1 minute.
+	Coo time.Duration
+
+	// Fai is the optional amount of failures that opens the circuit. Must not be
+	// negative. Defaults to 5.
+	Fai int
+
+	// Han is the worker handler implementing the actual business logic that
+	// should be protected by this circuit breaker.
+	Han handler.Ensure
+
+	// Log is a standard logger interface to forward structured log messages to
+	// any output interface e.g. stdout. All state transitions are logged.
+	Log logger.Interface
+
+	// Reg is the metrics registry used to expose the circuit state of the
+	// wrapped worker handler. The error filter of this registry is respected
+	// too, so that filtered errors are not considered failures.
+	Reg *registry.Registry
+
+	// Win is the optional sliding window in which failures are counted. If Win
+	// is provided, the circuit opens once Fai failures occurred within Win.
+	// Otherwise the circuit opens once Fai consecutive failures occurred. Must
+	// not be negative.
+	Win time.Duration
+}
+
+// Breaker is a wrapper handler implementing a circuit breaker for the wrapped
+// worker handler. Once the wrapped worker handler failed too often, the circuit
+// opens, and the wrapped worker handler is not executed anymore. After the
+// configured cooldown, the circuit half-opens in order to allow a single trial
+// execution. The circuit closes again if that trial execution succeeds, and
+// opens again otherwise.
+type Breaker struct {
+	handler.Forward
+
+	coo time.Duration
+	fai int
+	his []time.Time
+	log logger.Interface
+	mut sync.Mutex
+	nam string
+	now func() time.Time
+	ope time.Time
+	reg otelreg.Interface
+	sta string
+	tri atomic.Bool
+	win time.Duration
+	wrk *registry.Registry
+}
+
+func New(c Config) *Breaker {
+	if c.Coo == 0 {
+		c.Coo = time.Minute
+	}
+	if c.Coo < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Coo must not be negative", c)))
+	}
+	if c.Fai == 0 {
+		c.Fai = 5
+	}
+	if c.Fai < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Fai must not be negative", c)))
+	}
+	if c.Han == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not be empty", c)))
+	}
+	if c.Log == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
+	}
+	if c.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
+	}
+	if c.Win < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Win must not be negative", c)))
+	}
+
+	// Wrap the given worker handler within a proxy handler, so that we can
+	// forward all optional interfaces of the wrapped worker handler, regardless
+	// of whether they are implemented or not.
+
+	var pro handler.Interface
+	{
+		pro = proxy.New(proxy.Config{
+			Han: c.Han,
+		})
+	}
+
+	var nam string
+	{
+		nam = handler.Name(pro.Unwrap())
+	}
+
+	gau := map[string]registry.Metric{}
+
+	{
+		gau[MetricState] = registry.Metric{
+			Des: "the circuit state of worker handlers, 1 for the current state",
+			Lab: map[string][]string{
+				"handler": {nam},
+				"state":   {StateClosed, StateHalfOpen, StateOpen},
+			},
+		}
+	}
+
+	var reg otelreg.Interface
+	{
+		reg = c.Reg.Metrics(map[string]registry.Metric{}, gau, map[string]registry.Metric{})
+	}
+
+	var b *Breaker
+	{
+		b = &Breaker{
+			Forward: handler.Forward{Interface: pro},
+
+			coo: c.Coo,
+			fai: c.Fai,
+			log: c.Log,
+			nam: nam,
+			now: time.Now,
+			reg: reg,
+			sta: StateClosed,
+			win: c.Win,
+			wrk: c.Reg,
+		}
+	}
+
+	{
+		b.insSta()
+	}
+
+	return b
+}
@@ -0,0 +1,104 @@
+package breaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Ensure runs EnsureContext using the background context.
+func (b *Breaker) Ensure() error {
+	return b.EnsureContext(context.Background())
+}
+
+// EnsureContext executes the wrapped handler implementation, unless the circuit
+// is open, in which case OpenError is returned without executing the wrapped
+// handler implementation. A half-open circuit permits a single trial execution
+// at a time, so that all concurrent callers but one get OpenError as well. The
+// outcome of the execution determines the next circuit state.
+func (b *Breaker) EnsureContext(ctx context.Context) error {
+	var tri bool
+	{
+		b.mut.Lock()
+		b.cooled()
+		sta := b.sta
+		b.mut.Unlock()
+
+		if sta == StateOpen {
+			return tracer.Mask(OpenError, tracer.Context{Key: "handler", Value: b.nam})
+		}
+
+		if sta == StateHalfOpen {
+			tri = b.tri.CompareAndSwap(false, true)
+			if !tri {
+				return tracer.Mask(OpenError, tracer.Context{Key: "handler", Value: b.nam})
+			}
+		}
+	}
+
+	err := b.Interface.EnsureContext(ctx)
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if tri {
+		defer b.tri.Store(false)
+	}
+
+	if err == nil || b.wrk.Log(err) {
+		b.success()
+	} else {
+		b.failure()
+	}
+
+	return tracer.Mask(err)
+}
+
+// success closes a half-open circuit, and resets the consecutive failures of a
+// closed circuit. Note that the caller must hold the mutex.
+func (b *Breaker) success() {
+	if b.sta == StateHalfOpen {
+		b.transition(StateClosed)
+	} else if b.win == 0 {
+		b.his = nil
+	}
+}
+
+// failure opens a half-open circuit, and opens a closed circuit once too many
+// failures occurred. Note that the caller must hold the mutex.
+func (b *Breaker) failure() {
+	if b.sta == StateHalfOpen {
+		b.transition(StateOpen)
+		return
+	}
+
+	var now time.Time
+	{
+		now = b.now()
+	}
+
+	// Track the failure and drop all tracked failures that fell out of the
+	// sliding window, if any.
+
+	{
+		b.his = append(b.his, now)
+	}
+
+	if b.win != 0 {
+		var his []time.Time
+		for _, x := range b.his {
+			if now.Sub(x) < b.win {
+				his = append(his, x)
+			}
+		}
+
+		{
+			b.his = his
+		}
+	}
+
+	if len(b.his) >= b.fai {
+		b.transition(StateOpen)
+	}
+}
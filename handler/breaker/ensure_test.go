@@ -0,0 +1,357 @@
+package breaker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Handler_Breaker_Ensure verifies the state transitions of the circuit
+// breaker for a sequence of executions. Every step executes the wrapped worker
+// handler, unless the circuit is open, and advances the fake clock afterwards.
+func Test_Handler_Breaker_Ensure(t *testing.T) {
+	testCases := []struct {
+		win time.Duration
+		stp []testStep
+	}{
+		// Case 000, consecutive failures open the circuit, the cooldown half-opens
+		// the circuit, and a successful trial closes it again
+		{
+			stp: []testStep{
+				{err: true, adv: time.Second, sta: StateClosed},
+				{err: true, adv: time.Second, sta: StateOpen},
+				{err: false, adv: 30 * time.Second, sta: StateOpen},
+				{err: false, adv: 30 * time.Second, sta: StateHalfOpen},
+				{err: false, adv: time.Second, sta: StateClosed},
+			},
+		},
+		// Case 001, a failed trial opens the circuit again
+		{
+			stp: []testStep{
+				{err: true, adv: time.Second, sta: StateClosed},
+				{err: true, adv: time.Minute, sta: StateHalfOpen},
+				{err: true, adv: time.Second, sta: StateOpen},
+			},
+		},
+		// Case 002, successful executions reset consecutive failures
+		{
+			stp: []testStep{
+				{err: true, adv: time.Second, sta: StateClosed},
+				{err: false, adv: time.Second, sta: StateClosed},
+				{err: true, adv: time.Second, sta: StateClosed},
+				{err: true, adv: time.Second, sta: StateOpen},
+			},
+		},
+		// Case 003, windowed failures open the circuit despite successful
+		// executions in between
+		{
+			win: 10 * time.Second,
+			stp: []testStep{
+				{err: true, adv: time.Second, sta: StateClosed},
+				{err: false, adv: time.Second, sta: StateClosed},
+				{err: true, adv: time.Second, sta: StateOpen},
+			},
+		},
+		// Case 004, windowed failures falling out of the window are dropped
+		{
+			win: 10 * time.Second,
+			stp: []testStep{
+				{err: true, adv: 20 * time.Second, sta: StateClosed},
+				{err: true, adv: time.Second, sta: StateClosed},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var han *testHandler
+			{
+				han = &testHandler{}
+			}
+
+			var bre *Breaker
+			{
+				bre = New(Config{
+					Fai: 2,
+					Han: han,
+					Log: logger.Fake(),
+					Reg: tesReg(),
+					Win: tc.win,
+				})
+			}
+
+			var now time.Time
+			{
+				now = time.Now()
+			}
+
+			{
+				bre.now = func() time.Time { return now }
+			}
+
+			for j, x := range tc.stp {
+				{
+					han.err = x.err
+				}
+
+				if bre.Active() {
+					_ = bre.Ensure()
+				}
+
+				{
+					now = now.Add(x.adv)
+				}
+
+				if dif := cmp.Diff(x.sta, bre.State()); dif != "" {
+					t.Fatalf("step %d -expected +actual:\n%s", j, dif)
+				}
+			}
+		})
+	}
+}
+
+// Test_Handler_Breaker_Ensure_open verifies that the circuit breaker does not
+// execute the wrapped worker handler while the circuit is open.
+func Test_Handler_Breaker_Ensure_open(t *testing.T) {
+	var han *testHandler
+	{
+		han = &testHandler{err: true}
+	}
+
+	var bre *Breaker
+	{
+		bre = New(Config{
+			Fai: 1,
+			Han: han,
+			Log: logger.Fake(),
+			Reg: tesReg(),
+		})
+	}
+
+	{
+		_ = bre.Ensure()
+	}
+
+	if bre.Active() {
+		t.Fatal("expected", false, "got", true)
+	}
+
+	{
+		err := bre.Ensure()
+		if !IsOpen(err) {
+			t.Fatal("expected", OpenError, "got", err)
+		}
+	}
+
+	if dif := cmp.Diff(1, han.cal); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
+// Test_Handler_Breaker_Ensure_trial verifies that a half-open circuit permits
+// only a single trial execution, even if called concurrently.
+func Test_Handler_Breaker_Ensure_trial(t *testing.T) {
+	var han *trialHandler
+	{
+		han = &trialHandler{
+			rel: make(chan struct{}),
+			sig: make(chan struct{}, 10),
+		}
+	}
+
+	var bre *Breaker
+	{
+		bre = New(Config{
+			Fai: 1,
+			Han: han,
+			Log: logger.Fake(),
+			Reg: tesReg(),
+		})
+	}
+
+	var now time.Time
+	{
+		now = time.Now()
+	}
+
+	{
+		bre.now = func() time.Time { return now }
+	}
+
+	// Open the circuit and let its cooldown expire, so that the circuit
+	// half-opens.
+
+	{
+		han.err = true
+		close(han.rel)
+		_ = bre.Ensure()
+		han.err = false
+		han.rel = make(chan struct{})
+		<-han.sig
+	}
+
+	{
+		now = now.Add(time.Minute)
+	}
+
+	if dif := cmp.Diff(StateHalfOpen, bre.State()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	// Execute the half-open circuit concurrently while the trial execution is
+	// in flight. All other callers must be rejected right away.
+
+	var res chan error
+	{
+		res = make(chan error, 5)
+	}
+
+	for range 5 {
+		go func() {
+			res <- bre.Ensure()
+		}()
+	}
+
+	{
+		<-han.sig
+	}
+
+	for range 4 {
+		err := <-res
+		if !IsOpen(err) {
+			t.Fatal("expected", OpenError, "got", err)
+		}
+	}
+
+	if bre.Active() {
+		t.Fatal("expected", false, "got", true)
+	}
+
+	{
+		close(han.rel)
+	}
+
+	{
+		err := <-res
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if dif := cmp.Diff(StateClosed, bre.State()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
+// Test_Handler_Breaker_New_invalid verifies that the circuit breaker rejects
+// negative configuration values. Note that tracer.Panic exits the process,
+// which is why every invalid configuration is verified within its own process.
+func Test_Handler_Breaker_New_invalid(t *testing.T) {
+	testCases := []Config{
+		// Case 000
+		{Coo: -1},
+		// Case 001
+		{Fai: -1},
+		// Case 002
+		{Win: -1},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			if os.Getenv("BREAKER_INVALID") == strconv.Itoa(i) {
+				New(Config{
+					Coo: tc.Coo,
+					Fai: tc.Fai,
+					Han: &testHandler{},
+					Log: logger.Fake(),
+					Reg: tesReg(),
+					Win: tc.Win,
+				})
+
+				return
+			}
+
+			cmd := exec.Command(os.Args[0], "-test.run=^Test_Handler_Breaker_New_invalid$/^"+fmt.Sprintf("%03d", i)+"$")
+			cmd.Env = append(os.Environ(), "BREAKER_INVALID="+strconv.Itoa(i))
+
+			err := cmd.Run()
+			if err == nil {
+				t.Fatal("expected", "exit status 1", "got", nil)
+			}
+		})
+	}
+}
+
+type testStep struct {
+	err bool
+	adv time.Duration
+	sta string
+}
+
+type testHandler struct {
+	cal int
+	err bool
+}
+
+func (h *testHandler) Active() bool {
+	return true
+}
+
+func (h *testHandler) Ensure() error {
+	{
+		h.cal++
+	}
+
+	if h.err {
+		return errors.New("test error")
+	}
+
+	return nil
+}
+
+type trialHandler struct {
+	err bool
+	rel chan struct{}
+	sig chan struct{}
+}
+
+func (h *trialHandler) Active() bool {
+	return true
+}
+
+func (h *trialHandler) Ensure() error {
+	{
+		h.sig <- struct{}{}
+	}
+
+	{
+		<-h.rel
+	}
+
+	if h.err {
+		return errors.New("test error")
+	}
+
+	return nil
+}
+
+func tesReg() *registry.Registry {
+	return registry.New(registry.Config{
+		Env: "testing",
+		Log: logger.Fake(),
+		Met: recorder.NewMeter(recorder.MeterConfig{
+			Env: "testing",
+			Sco: "workit",
+			Ver: "v0.1.0",
+		}),
+	})
+}
@@ -0,0 +1,18 @@
+package breaker
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// OpenError is returned by the circuit breaker if its wrapped worker handler
+// is executed while the circuit is open.
+var OpenError = &tracer.Error{
+	Description: "The circuit breaker is open and does not execute the worker handler.",
+}
+
+// IsOpen returns true if the given error is or wraps OpenError.
+func IsOpen(err error) bool {
+	return errors.Is(err, OpenError)
+}
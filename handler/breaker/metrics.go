@@ -0,0 +1,29 @@
+package breaker
+
+import "github.com/xh3b4sd/tracer"
+
+// insSta records the current circuit state, so that dashboards can show which
+// worker handlers are tripped. Note that the caller must hold the mutex, unless
+// the circuit breaker is still being constructed.
+func (b *Breaker) insSta() {
+	for _, x := range []string{StateClosed, StateHalfOpen, StateOpen} {
+		var val float64
+		if x == b.sta {
+			val = 1
+		}
+
+		lab := map[string]string{
+			"handler": b.nam,
+			"state":   x,
+		}
+
+		err := b.reg.Gauge(MetricState, val, lab)
+		if err != nil {
+			b.log.Log(
+				"level", "error",
+				"message", "worker instrumentation failed",
+				"stack", tracer.Json(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package breaker
+
+// State returns the current circuit state of this circuit breaker, which is
+// one of StateClosed, StateHalfOpen or StateOpen.
+func (b *Breaker) State() string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	{
+		b.cooled()
+	}
+
+	return b.sta
+}
+
+// cooled half-opens the open circuit once its cooldown expired. Note that the
+// caller must hold the mutex.
+func (b *Breaker) cooled() {
+	if b.sta == StateOpen && b.now().Sub(b.ope) >= b.coo {
+		b.transition(StateHalfOpen)
+	}
+}
+
+// transition moves the circuit into the given state, logs the state change and
+// updates the state gauge. Note that the caller must hold the mutex.
+func (b *Breaker) transition(sta string) {
+	if b.sta == sta {
+		return
+	}
+
+	var lev string
+	if sta == StateOpen {
+		lev = "warning"
+	} else {
+		lev = "info"
+	}
+
+	b.log.Log(
+		"level", lev,
+		"message", "circuit breaker changed state",
+		"handler", b.nam,
+		"from", b.sta,
+		"to", sta,
+	)
+
+	if sta == StateOpen {
+		b.ope = b.now()
+	}
+
+	{
+		b.his = nil
+		b.sta = sta
+	}
+
+	{
+		b.insSta()
+	}
+}
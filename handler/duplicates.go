@@ -0,0 +1,27 @@
+package handler
+
+import "slices"
+
+// Duplicates returns the sorted list of worker handler names occurring more
+// than once within the given list of worker handler names. E.g. this function
+// should enable worker engines to reject colliding worker handlers early on.
+func Duplicates(nam []string) []string {
+	var cou map[string]int
+	{
+		cou = map[string]int{}
+	}
+
+	var dup []string
+	for _, x := range nam {
+		cou[x]++
+		if cou[x] == 2 {
+			dup = append(dup, x)
+		}
+	}
+
+	{
+		slices.Sort(dup)
+	}
+
+	return dup
+}
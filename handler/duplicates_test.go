@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Handler_Duplicates(t *testing.T) {
+	testCases := []struct {
+		nam []string
+		dup []string
+	}{
+		// Case 000
+		{
+			nam: nil,
+			dup: nil,
+		},
+		// Case 001
+		{
+			nam: []string{"foo", "bar", "baz"},
+			dup: nil,
+		},
+		// Case 002
+		{
+			nam: []string{"foo", "bar", "foo", "baz", "bar", "foo"},
+			dup: []string{"bar", "foo"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			dup := Duplicates(tc.nam)
+			if dif := cmp.Diff(tc.dup, dup); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
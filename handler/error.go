@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// PanicError is returned by the internally wrapped worker handlers if the
+// underlying handler implementation panicked during execution.
+var PanicError = &tracer.Error{
+	Description: "The worker handler panicked during execution.",
+}
+
+// IsPanic returns true if the given error is or wraps PanicError.
+func IsPanic(err error) bool {
+	return errors.Is(err, PanicError)
+}
+
+// TimeoutError is returned by the internally wrapped worker handlers if the
+// underlying handler implementation did not finish within its timeout.
+var TimeoutError = &tracer.Error{
+	Description: "The worker handler did not finish within its configured timeout.",
+}
+
+// IsTimeout returns true if the given error is or wraps TimeoutError.
+func IsTimeout(err error) bool {
+	return errors.Is(err, TimeoutError)
+}
+
+// Recovered converts the given value recovered from a panic into PanicError.
+// The returned error is annotated with the recovered value and the stack trace
+// of the calling goroutine. Recovered must therefore be called within the
+// deferred function that recovered the panic.
+func Recovered(rec any) error {
+	return tracer.Mask(
+		PanicError,
+		tracer.Context{Key: "panic", Value: fmt.Sprint(rec)},
+		tracer.Context{Key: "stack", Value: string(debug.Stack())},
+	)
+}
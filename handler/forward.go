@@ -0,0 +1,27 @@
+package handler
+
+// Forward is an embeddable base type for wrapper handlers, e.g. custom
+// middlewares, forwarding every method of Interface to the wrapped handler, and
+// implementing Wrapper, so that Stack can inspect the full wrapper stack.
+// Wrapper handlers embedding Forward only implement the methods whose behaviour
+// they change. Note that worker engines execute worker handlers exclusively via
+// EnsureContext, which is why wrapper handlers changing the execution must
+// override EnsureContext. Overriding Ensure only is bypassed by the worker
+// engines.
+//
+//	type middleware struct {
+//		handler.Forward
+//	}
+//
+//	func (m *middleware) EnsureContext(ctx context.Context) error {
+//		return m.Interface.EnsureContext(ctx)
+//	}
+type Forward struct {
+	Interface
+}
+
+// Wrapped returns the handler directly wrapped by the embedding wrapper
+// handler.
+func (f Forward) Wrapped() Ensure {
+	return f.Interface
+}
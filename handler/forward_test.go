@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Test_Handler_Forward verifies that middlewares embedding Forward forward all
+// methods of the wrapped handler, and that only middlewares overriding
+// EnsureContext take effect, because worker engines execute worker handlers
+// exclusively via EnsureContext.
+func Test_Handler_Forward(t *testing.T) {
+	testCases := []struct {
+		mid func(Interface, *[]string) Interface
+		cal []string
+	}{
+		// Case 000, middleware overriding EnsureContext
+		{
+			mid: func(han Interface, cal *[]string) Interface {
+				return &contextMiddleware{Forward: Forward{Interface: han}, cal: cal}
+			},
+			cal: []string{"middleware", "handler"},
+		},
+		// Case 001, middleware overriding Ensure only is bypassed
+		{
+			mid: func(han Interface, cal *[]string) Interface {
+				return &ensureMiddleware{Forward: Forward{Interface: han}, cal: cal}
+			},
+			cal: []string{"handler"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var cal []string
+
+			var fun *Func
+			{
+				fun = NewFunc(FuncConfig{
+					Coo: time.Minute,
+					Fun: func() error {
+						cal = append(cal, "handler")
+						return nil
+					},
+					Nam: "foo",
+					Tim: time.Second,
+				})
+			}
+
+			var han Interface
+			{
+				han = Chain(fun, func(han Interface) Interface {
+					return tc.mid(han, &cal)
+				})
+			}
+
+			err := han.EnsureContext(context.Background())
+			if err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+
+			if dif := cmp.Diff(tc.cal, cal); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(time.Minute, han.Cooler()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(time.Second, han.Timeout()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(2, len(Stack(han))); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+type contextMiddleware struct {
+	Forward
+
+	cal *[]string
+}
+
+func (m *contextMiddleware) EnsureContext(ctx context.Context) error {
+	*m.cal = append(*m.cal, "middleware")
+	return m.Interface.EnsureContext(ctx)
+}
+
+type ensureMiddleware struct {
+	Forward
+
+	cal *[]string
+}
+
+func (m *ensureMiddleware) Ensure() error {
+	*m.cal = append(*m.cal, "middleware")
+	return m.Interface.Ensure()
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/schedule"
+	"github.com/xh3b4sd/tracer"
+)
+
+type FuncConfig struct {
+	// Act is the optional active predicate evaluated before every execution.
+	// The worker handler is always active by default.
+	Act func() bool
+
+	// Coo is the optional cooler of the worker handler, which is only relevant
+	// for worker handlers executed by the *parallel.Worker engine.
+	Coo time.Duration
+
+	// Ctx is the business logic of the worker handler, receiving the execution
+	// context of the calling worker engine. Either Ctx or Fun must be provided.
+	Ctx func(context.Context) error
+
+	// Fun is the business logic of the worker handler, ignoring the execution
+	// context of the calling worker engine. Either Ctx or Fun must be provided.
+	Fun func() error
+
+	// Nam is the explicit name of the worker handler, which must be unique
+	// within the worker engine executing it.
+	Nam string
+
+	// Tim is the optional execution timeout of the worker handler. See
+	// handler.Timeout for more information.
+	Tim time.Duration
+}
+
+// Func is a worker handler adapter for plain functions, so that worker handlers
+// can be defined without declaring their own types, e.g. in tests. Func
+// implements Interface, as well as Named, using its explicit name.
+type Func struct {
+	act func() bool
+	coo time.Duration
+	ctx func(context.Context) error
+	nam string
+	tim time.Duration
+}
+
+func NewFunc(c FuncConfig) *Func {
+	if c.Act == nil {
+		c.Act = func() bool { return true }
+	}
+	if c.Ctx == nil && c.Fun == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Ctx or %T.Fun must not be empty", c, c)))
+	}
+	if c.Ctx != nil && c.Fun != nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Ctx and %T.Fun must not be provided together", c, c)))
+	}
+	if c.Ctx == nil {
+		c.Ctx = func(_ context.Context) error { return c.Fun() }
+	}
+	if c.Nam == "" {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Nam must not be empty", c)))
+	}
+
+	return &Func{
+		act: c.Act,
+		coo: c.Coo,
+		ctx: c.Ctx,
+		nam: c.Nam,
+		tim: c.Tim,
+	}
+}
+
+// Active returns the result of the configured active predicate.
+func (f *Func) Active() bool {
+	return f.act()
+}
+
+// Cooler returns the configured cooler.
+func (f *Func) Cooler() time.Duration {
+	return f.coo
+}
+
+// Delay returns zero, so that the initial delay of the worker engine applies.
+func (f *Func) Delay() time.Duration {
+	return 0
+}
+
+// Ensure runs EnsureContext using the background context.
+func (f *Func) Ensure() error {
+	return f.EnsureContext(context.Background())
+}
+
+// EnsureContext executes the configured business logic.
+func (f *Func) EnsureContext(ctx context.Context) error {
+	return f.ctx(ctx)
+}
+
+// Group returns an empty string, so that no resource group applies.
+func (f *Func) Group() string {
+	return ""
+}
+
+// Jitter returns zero, so that the jitter of the worker engine applies.
+func (f *Func) Jitter() float64 {
+	return 0
+}
+
+// Locker returns nil, so that the locker of the worker engine applies.
+func (f *Func) Locker() locker.Interface {
+	return nil
+}
+
+// Name returns the configured explicit name.
+func (f *Func) Name() string {
+	return f.nam
+}
+
+// Schedule returns nil, so that the configured cooler applies.
+func (f *Func) Schedule() schedule.Interface {
+	return nil
+}
+
+// Timeout returns the configured execution timeout.
+func (f *Func) Timeout() time.Duration {
+	return f.tim
+}
+
+// Unwrap returns the function adapter itself, because it does not wrap any
+// other worker handler.
+func (f *Func) Unwrap() Ensure {
+	return f
+}
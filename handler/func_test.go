@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testKey struct{}
+
+func Test_Handler_Func(t *testing.T) {
+	testCases := []struct {
+		con FuncConfig
+		act bool
+		coo time.Duration
+		err error
+		tim time.Duration
+	}{
+		// Case 000, plain function with defaults
+		{
+			con: FuncConfig{
+				Fun: func() error { return nil },
+				Nam: "foo",
+			},
+			act: true,
+			coo: 0,
+			err: nil,
+			tim: 0,
+		},
+		// Case 001, plain function with options
+		{
+			con: FuncConfig{
+				Act: func() bool { return false },
+				Coo: time.Minute,
+				Fun: func() error { return errors.New("test error") },
+				Nam: "foo",
+				Tim: time.Second,
+			},
+			act: false,
+			coo: time.Minute,
+			err: errors.New("test error"),
+			tim: time.Second,
+		},
+		// Case 002, context function receiving the execution context
+		{
+			con: FuncConfig{
+				Ctx: func(ctx context.Context) error {
+					if ctx.Value(testKey{}) != "bar" {
+						return errors.New("context missing")
+					}
+					return nil
+				},
+				Nam: "foo",
+			},
+			act: true,
+			coo: 0,
+			err: nil,
+			tim: 0,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var han Interface
+			{
+				han = NewFunc(tc.con)
+			}
+
+			if dif := cmp.Diff(tc.act, han.Active()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(tc.coo, han.Cooler()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(tc.tim, han.Timeout()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff("foo", Name(han.Unwrap())); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(1, len(Stack(han))); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			err := han.EnsureContext(context.WithValue(context.Background(), testKey{}, "bar"))
+			if fmt.Sprint(err) != fmt.Sprint(tc.err) {
+				t.Fatalf("expected %#v got %#v", tc.err, err)
+			}
+		})
+	}
+}
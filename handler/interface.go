@@ -1,6 +1,12 @@
 package handler
 
-import "time"
+import (
+	"context"
+	"time"
+
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/schedule"
+)
 
 // Interface describes the internally wrapped worker handlers used for proper
 // management inside of the various worker engines. External users do usually
@@ -8,6 +14,12 @@ import "time"
 type Interface interface {
 	Cooler
 	Ensure
+	EnsureContext
+	Group
+	Jitter
+	Locker
+	Schedule
+	Timeout
 	Unwrap
 }
 
@@ -20,6 +32,18 @@ type Active interface {
 	Active() bool
 }
 
+// Buckets is an optional interface that allows worker handlers to define their
+// own histogram bucket boundaries for their execution duration, e.g. for worker
+// handlers taking minutes, or only milliseconds. The execution duration of such
+// worker handlers is recorded within the shared histogram family, using a
+// dedicated meter created via the meter provider of the registry.
+type Buckets interface {
+	// Buckets returns the histogram bucket boundaries in seconds, sorted in
+	// strictly increasing order. Returning nil falls back to the bucket
+	// boundaries configured on the registry.
+	Buckets() []float64
+}
+
 // Cooler is manadatory to be implemented for worker handlers executed by the
 // *parallel.Worker engine, because those worker handlers do all run inside
 // their own isolated failure domains, which require individual cooler durations
@@ -47,6 +71,119 @@ type Ensure interface {
 	Ensure() error
 }
 
+// EnsureContext is an optional extension of Ensure for worker handlers that
+// want to observe cancellation signals of the worker engine executing them.
+// Worker engines prefer EnsureContext over Ensure if the underlying handler
+// implementation provides it.
+type EnsureContext interface {
+	// EnsureContext executes the handler specific business logic like Ensure,
+	// but receives the execution context of the calling worker engine. The given
+	// context is cancelled once the worker engine stopped and the configured
+	// grace period for in-flight executions expired.
+	EnsureContext(ctx context.Context) error
+}
+
+// Group is an optional scheduler primitive that allows worker handlers to opt
+// into a resource group, e.g. a shared RPC provider or database pool. Worker
+// engines bound the number of concurrent executions of all worker handlers
+// sharing the same resource group, according to the limits of the configured
+// *semaphore.Semaphore.
+type Group interface {
+	// Group returns the name of the resource group of the underlying handler.
+	// Returning an empty string opts out of any resource group.
+	Group() string
+}
+
+// Jitter is an optional scheduler primitive for worker handlers executed by
+// the *parallel.Worker engine. Worker handlers implementing Jitter define their
+// own initial delay and random jitter, so that their executions are spread
+// over time, instead of hitting shared backends in lockstep with all other
+// replicas.
+type Jitter interface {
+	// Delay is the amount of time to wait before the first execution of the
+	// underlying handler. A zero duration falls back to the initial delay
+	// configured on the worker engine, if any. A negative duration disables the
+	// initial delay for the underlying handler.
+	Delay() time.Duration
+
+	// Jitter is the fraction of random jitter applied to the initial delay and
+	// to every cooler of the underlying handler, e.g. 0.1 for +-10%. A zero
+	// fraction falls back to the jitter configured on the worker engine, if any.
+	// A negative fraction disables jitter for the underlying handler. Must be
+	// below 1.
+	Jitter() float64
+}
+
+// Labeled is an optional interface that allows worker handlers to define
+// additional static labels, e.g. the owning team or the business domain of the
+// underlying handler. Those labels are added to all execution metrics and to
+// all errors of the underlying handler, so that they show up in the logs.
+type Labeled interface {
+	// Labels returns the static label keys and label values of the underlying
+	// handler. Labels must return the same labels throughout the lifetime of the
+	// underlying handler, and must not use the label keys reserved by the
+	// execution metrics, e.g. "handler".
+	Labels() map[string]string
+}
+
+// Locker is an optional scheduler primitive that allows worker handlers to opt
+// into leader election individually. Worker engines only execute worker
+// handlers implementing Locker while holding the lease of the underlying
+// handler, so that only a single replica executes the handler at a time.
+type Locker interface {
+	// Locker returns the locker used to acquire the lease of the underlying
+	// handler. Returning nil falls back to the locker configured on the worker
+	// engine, if any.
+	Locker() locker.Interface
+}
+
+// Named is an optional interface that allows worker handlers to define their
+// own name, instead of being named after their package declaration. Explicit
+// names are necessary for multiple worker handlers of the same package, which
+// would otherwise collide within the same worker engine.
+type Named interface {
+	// Name returns the unique name of the underlying handler. Returning an empty
+	// string falls back to the package declaration of the underlying handler.
+	Name() string
+}
+
+// Pending is an administrative interface that is most useful for our internal
+// wrapper handlers, e.g. proxy, which may abandon executions that keep running
+// in the background. Worker engines use Pending to hold on to the concurrency
+// limits of abandoned executions until those executions finally returned.
+type Pending interface {
+	// Pending returns a channel that is closed once the last abandoned execution
+	// of the underlying handler returned. The returned channel is closed right
+	// away if there is no abandoned execution.
+	Pending() <-chan struct{}
+}
+
+// Schedule is an optional scheduler primitive for worker handlers executed by
+// the *parallel.Worker engine. Worker handlers implementing Schedule are
+// executed on a strict schedule, e.g. on fixed wall clock intervals or
+// according to a cron expression, instead of sleeping for their cooler after
+// every execution.
+type Schedule interface {
+	// Schedule returns the strict schedule that the worker engine should execute
+	// the underlying handler on. Returning nil falls back to the cooler of the
+	// underlying handler.
+	Schedule() schedule.Interface
+}
+
+// Timeout is an optional scheduler primitive that allows worker handlers to
+// define their own execution timeout. Worker engines abandon any execution of
+// worker handlers exceeding their timeout, and cancel the execution context
+// provided to worker handlers implementing handler.EnsureContext. The next
+// execution of the same worker handler waits for any abandoned execution to
+// return first, so that worker handlers are never executed concurrently.
+type Timeout interface {
+	// Timeout is the maximum amount of time that any given handler execution may
+	// take before being abandoned by the worker engine. A zero duration falls
+	// back to the default timeout configured on the worker engine, if any. A
+	// negative duration disables timeouts for the underlying handler.
+	Timeout() time.Duration
+}
+
 // Unwrap is an administrative interface that is most useful for our internal
 // wrapper handlers, e.g. metrics and proxy. Most users do not have to worry
 // about this.
@@ -54,3 +191,13 @@ type Unwrap interface {
 	// Unwrap returns the underlying worker handler implementation, if any.
 	Unwrap() Ensure
 }
+
+// Wrapper is an administrative interface that is most useful for our internal
+// wrapper handlers, e.g. metrics and proxy, as well as for custom middlewares.
+// Other than Unwrap, Wrapper only descends a single level of the wrapper stack,
+// so that the full wrapper stack can be inspected via Stack.
+type Wrapper interface {
+	// Wrapped returns the worker handler directly wrapped by the underlying
+	// wrapper handler.
+	Wrapped() Ensure
+}
@@ -1,8 +1,31 @@
 package metrics
 
+import "github.com/xh3b4sd/tracer"
+
 // Active only forwards the scheduler primitive of the wrapped handler
 // implementation. That means the metrics handler does not have its own
 // activation setting, but only acts as proxy for the underlying handler.
+// Every inactive reconciliation loop is instrumented as skipped execution.
 func (m *Metrics) Active() bool {
-	return m.han.Active()
+	act := m.Interface.Active()
+	if !act {
+		m.insSki()
+	}
+
+	return act
+}
+
+func (m *Metrics) insSki() {
+	lab := m.labels(map[string]string{
+		"handler": m.nam,
+	})
+
+	err := m.reg.Counter(MetricSkipped, 1, lab)
+	if err != nil {
+		m.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
 }
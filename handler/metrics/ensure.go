@@ -1,18 +1,31 @@
 package metrics
 
 import (
+	"context"
+	"maps"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/0xSplits/workit/handler"
 	"github.com/xh3b4sd/tracer"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Ensure tracks the start time of its own execution and runs the business logic
-// of the wrapped worker handler. The wrapped business logic is instrumented for
-// runtime latency and error rates. Note that Ensure emits debug logs about the
-// internal worker handler execution. Any error returned originates from the
-// underlying handler implementation, not from the metrics collection process.
+// Ensure runs EnsureContext using the background context, so that wrapped
+// worker handlers are instrumented the same way regardless of the interface
+// used by the calling worker engine.
 func (m *Metrics) Ensure() error {
+	return m.EnsureContext(context.Background())
+}
+
+// EnsureContext tracks the start time of its own execution and runs the
+// business logic of the wrapped worker handler. The wrapped business logic is
+// instrumented for runtime latency and error rates. Note that EnsureContext
+// emits debug logs about the internal worker handler execution. Any error
+// returned originates from the underlying handler implementation, not from the
+// metrics collection process.
+func (m *Metrics) EnsureContext(ctx context.Context) error {
 	// Record the start time for our handler latency. The timezone of the duration
 	// measurement is irrelavant here, so we are not using time.Now().UTC() as a
 	// best practice like we would in other places.
@@ -22,14 +35,38 @@ func (m *Metrics) Ensure() error {
 		sta = time.Now()
 	}
 
+	// Track the amount of executions currently in flight, so that e.g. stuck
+	// worker handlers can be detected.
+
+	{
+		m.insInf(m.inf.Add(1))
+	}
+
+	defer func() {
+		m.insInf(m.inf.Add(-1))
+	}()
+
 	// Note that we cannot return the error from the handler execution, because we
 	// want to monitor the failure latency as well, if possible. So instead of
 	// returning the error early during the error case, we simply log the error
 	// and continue below.
 
+	// Trace every execution within its own span, which is provided to the
+	// wrapped handler via the given context, so that e.g. downstream RPC calls
+	// can join the same trace.
+
+	var spn trace.Span
+	{
+		ctx, spn = m.tra.Start(ctx, m.nam, trace.WithAttributes(m.attributes()...))
+	}
+
 	var err error
 	{
-		err = m.han.Ensure()
+		err = m.Interface.EnsureContext(ctx)
+	}
+
+	{
+		m.trace(spn, err)
 	}
 
 	// Record the handler latency immediately after the handler execution. The
@@ -39,9 +76,19 @@ func (m *Metrics) Ensure() error {
 
 	{
 		m.insHan(sta, err)
+		m.insFai(err)
+		m.insSuc(err)
+	}
+
+	// Annotate any error with the static labels of the underlying handler. Note
+	// that the error is masked beforehand, so that the labels are only added
+	// once, regardless of the error type returned by the underlying handler.
+
+	if err != nil {
+		return tracer.Mask(tracer.Mask(err), m.context()...)
 	}
 
-	return tracer.Mask(err)
+	return nil
 }
 
 func (m *Metrics) insHan(sta time.Time, err error) {
@@ -52,18 +99,24 @@ func (m *Metrics) insHan(sta time.Time, err error) {
 		suc = strconv.FormatBool(err == nil || m.fil(err))
 	}
 
-	m.log.Log(
+	pai := []string{
 		"level", "debug",
 		"message", "instrumented worker handler",
 		"handler", m.nam,
 		"latency", lat.String(),
 		"success", suc,
-	)
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(m.lab)) {
+		pai = append(pai, k, m.lab[k])
+	}
+
+	m.log.Log(pai...)
 
-	lab := map[string]string{
+	lab := m.labels(map[string]string{
 		"handler": m.nam,
 		"success": suc,
-	}
+	})
 
 	err = m.reg.Counter(MetricTotal, 1, lab)
 	if err != nil {
@@ -83,3 +136,88 @@ func (m *Metrics) insHan(sta time.Time, err error) {
 		)
 	}
 }
+
+// insFai records the reason of failed worker handler executions, so that e.g.
+// timeouts and panics can be distinguished from errors returned by the
+// underlying handler implementation. Note that filtered errors are not
+// considered failures.
+func (m *Metrics) insFai(err error) {
+	if err == nil || m.fil(err) {
+		return
+	}
+
+	var rea string
+	if handler.IsPanic(err) {
+		rea = ReasonPanic
+	} else if handler.IsTimeout(err) {
+		rea = ReasonTimeout
+	} else {
+		rea = ReasonError
+	}
+
+	lab := m.labels(map[string]string{
+		"handler": m.nam,
+		"reason":  rea,
+	})
+
+	err = m.reg.Counter(MetricFailure, 1, lab)
+	if err != nil {
+		m.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
+
+// insInf records the given amount of executions currently in flight.
+func (m *Metrics) insInf(inf int64) {
+	lab := m.labels(map[string]string{
+		"handler": m.nam,
+	})
+
+	err := m.reg.Gauge(MetricInflight, float64(inf), lab)
+	if err != nil {
+		m.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
+
+// insSuc records the time of the last successful execution and the amount of
+// consecutive failed executions, which is reset by every successful execution.
+// Note that filtered errors are not considered failures.
+func (m *Metrics) insSuc(err error) {
+	lab := m.labels(map[string]string{
+		"handler": m.nam,
+	})
+
+	var con int64
+	if err == nil || m.fil(err) {
+		m.con.Store(0)
+	} else {
+		con = m.con.Add(1)
+	}
+
+	if con == 0 {
+		err = m.reg.Gauge(MetricSuccess, float64(time.Now().Unix()), lab)
+		if err != nil {
+			m.log.Log(
+				"level", "error",
+				"message", "worker instrumentation failed",
+				"stack", tracer.Json(err),
+			)
+		}
+	}
+
+	err = m.reg.Gauge(MetricConsecutive, float64(con), lab)
+	if err != nil {
+		m.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
@@ -0,0 +1,28 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// labels adds the static labels of the underlying handler to the given metric
+// labels, so that all execution metrics can be queried by e.g. team.
+func (m *Metrics) labels(lab map[string]string) map[string]string {
+	maps.Copy(lab, m.lab)
+	return lab
+}
+
+// context returns the static labels of the underlying handler as error
+// context, sorted by label key, so that they show up in the error logs of the
+// calling worker engine.
+func (m *Metrics) context() []tracer.Context {
+	var ctx []tracer.Context
+
+	for _, k := range slices.Sorted(maps.Keys(m.lab)) {
+		ctx = append(ctx, tracer.Context{Key: k, Value: m.lab[k]})
+	}
+
+	return ctx
+}
@@ -2,32 +2,53 @@ package metrics
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/0xSplits/otelgo/registry"
 	"github.com/0xSplits/workit/handler"
 	"github.com/xh3b4sd/logger"
 	"github.com/xh3b4sd/tracer"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	MetricTotal    = "worker_handler_execution_total"
-	MetricDuration = "worker_handler_execution_duration_seconds"
+	MetricTotal       = "worker_handler_execution_total"
+	MetricDuration    = "worker_handler_execution_duration_seconds"
+	MetricFailure     = "worker_handler_failure_total"
+	MetricSkipped     = "worker_handler_skipped_total"
+	MetricConsecutive = "worker_handler_consecutive_failures"
+	MetricInflight    = "worker_handler_inflight_executions"
+	MetricSuccess     = "worker_handler_last_success_timestamp_seconds"
+	MetricCooler      = "worker_handler_cooler_duration_seconds"
+)
+
+const (
+	ReasonError   = "error"
+	ReasonPanic   = "panic"
+	ReasonTimeout = "timeout"
 )
 
 type Config struct {
 	Fil func(error) bool
 	Han handler.Interface
+	Lab map[string]string
 	Log logger.Interface
 	Nam string
 	Reg registry.Interface
+	Tra trace.Tracer
 }
 
 type Metrics struct {
+	handler.Forward
+
+	con atomic.Int64
 	fil func(error) bool
-	han handler.Interface
+	inf atomic.Int64
+	lab map[string]string
 	log logger.Interface
 	nam string
 	reg registry.Interface
+	tra trace.Tracer
 }
 
 func New(c Config) *Metrics {
@@ -46,12 +67,18 @@ func New(c Config) *Metrics {
 	if c.Reg == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%TReg must not be empty", c)))
 	}
+	if c.Tra == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Tra must not be empty", c)))
+	}
 
 	return &Metrics{
+		Forward: handler.Forward{Interface: c.Han},
+
 		fil: c.Fil,
-		han: c.Han,
+		lab: c.Lab,
 		log: c.Log,
 		nam: c.Nam,
 		reg: c.Reg,
+		tra: c.Tra,
 	}
 }
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributes returns the handler name and the static labels of the underlying
+// handler as span attributes, sorted by label key.
+func (m *Metrics) attributes() []attribute.KeyValue {
+	att := []attribute.KeyValue{
+		attribute.String("handler", m.nam),
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(m.lab)) {
+		att = append(att, attribute.String(k, m.lab[k]))
+	}
+
+	return att
+}
+
+// trace records the given error on the given span and ends it. Note that
+// filtered errors are recorded, but do not mark the span as failed, just like
+// filtered errors are not considered failures by the execution metrics.
+func (m *Metrics) trace(spn trace.Span, err error) {
+	if err != nil {
+		spn.RecordError(err)
+	}
+
+	if err != nil && !m.fil(err) {
+		spn.SetStatus(codes.Error, err.Error())
+	}
+
+	{
+		spn.End()
+	}
+}
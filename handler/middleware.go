@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Middleware wraps the given worker handler within another worker handler, e.g.
+// to add logging, tracing or custom admission control around every execution.
+// Middlewares must preserve the Unwrap behaviour of the handler they wrap, so
+// that Name still resolves the underlying handler implementation. Embedding
+// Forward is the simplest way to do so, which implements Wrapper as well, so
+// that Stack can inspect the full wrapper stack. Worker engines execute worker
+// handlers exclusively via EnsureContext, which is why middlewares changing the
+// execution must override EnsureContext, and not just Ensure.
+type Middleware func(Interface) Interface
+
+// Chain wraps the given worker handler within all of the given middlewares in
+// order, so that the first middleware becomes the outermost wrapper, and is
+// therefore executed first. Chain panics if any middleware returns nil, or does
+// not preserve the underlying handler implementation resolved by Unwrap.
+//
+//	mid[0] -> mid[1] -> han
+func Chain(han Interface, mid ...Middleware) Interface {
+	var nam string
+	{
+		nam = Name(han.Unwrap())
+	}
+
+	for i := len(mid) - 1; i >= 0; i-- {
+		han = mid[i](han)
+		if han == nil {
+			tracer.Panic(tracer.Mask(fmt.Errorf("middleware %d must not return nil for handler %s", i, nam)))
+		}
+		if Name(han.Unwrap()) != nam {
+			tracer.Panic(tracer.Mask(fmt.Errorf("middleware %d must preserve the unwrap of handler %s", i, nam)))
+		}
+	}
+
+	return han
+}
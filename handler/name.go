@@ -5,8 +5,15 @@ import (
 	"strings"
 )
 
-// Name returns the package declaration of the given handler implementation.
+// Name returns the name of the given handler implementation, which is the
+// name returned by Named if the given handler implements it, or the package
+// declaration of the given handler implementation otherwise.
 func Name(h Ensure) string {
+	n, i := h.(Named)
+	if i && n.Name() != "" {
+		return n.Name()
+	}
+
 	//
 	//     *artefact.Handler
 	//
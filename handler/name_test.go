@@ -30,6 +30,16 @@ func Test_Handler_Name(t *testing.T) {
 			han: &operator.Operator{},
 			nam: "operator",
 		},
+		// Case 003
+		{
+			han: &namedHandler{nam: "prices"},
+			nam: "prices",
+		},
+		// Case 004
+		{
+			han: &namedHandler{},
+			nam: "handler",
+		},
 	}
 
 	for i, tc := range testCases {
@@ -41,3 +51,19 @@ func Test_Handler_Name(t *testing.T) {
 		})
 	}
 }
+
+type namedHandler struct {
+	nam string
+}
+
+func (h *namedHandler) Active() bool {
+	return true
+}
+
+func (h *namedHandler) Ensure() error {
+	return nil
+}
+
+func (h *namedHandler) Name() string {
+	return h.nam
+}
@@ -0,0 +1,18 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+)
+
+// Delay returns the initial delay of the underlying worker handler if that
+// handler implements the handler.Jitter interface. Otherwise 0 is returned.
+func (p *Proxy) Delay() time.Duration {
+	v, i := p.han.(handler.Jitter)
+	if i {
+		return v.Delay()
+	}
+
+	return 0
+}
@@ -1,7 +1,133 @@
 package proxy
 
+import (
+	"context"
+	"errors"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
 // Ensure executes the business logic of the wrapped worker handler
 // transparently without any additional behaviour change.
 func (p *Proxy) Ensure() error {
 	return p.han.Ensure()
 }
+
+// EnsureContext executes the business logic of the wrapped worker handler with
+// the given context if that handler implements the handler.EnsureContext
+// interface. Otherwise the context is dropped and Ensure is called instead.
+//
+// EnsureContext returns as soon as the given context is done, even if the
+// wrapped worker handler is still executing. The abandoned execution is left
+// to finish in the background, and any subsequent execution waits for the
+// abandoned execution to return first, so that the wrapped worker handler is
+// never executed concurrently. Executions exceeding the deadline of the given
+// context result in handler.TimeoutError, and panicking executions result in
+// handler.PanicError.
+func (p *Proxy) EnsureContext(ctx context.Context) error {
+	err := p.wait(ctx)
+	if err != nil {
+		return timeout(ctx, err)
+	}
+
+	// Execute the wrapped worker handler synchronously if the given context can
+	// never be done, because there is nothing to abandon in that case.
+
+	if ctx.Done() == nil {
+		return p.ensure(ctx)
+	}
+
+	// Note that the result channel is buffered, so that abandoned executions do
+	// not block forever once they finally return.
+
+	var don chan struct{}
+	var res chan error
+	{
+		don = make(chan struct{})
+		res = make(chan error, 1)
+	}
+
+	go func() {
+		defer close(don)
+		res <- p.ensure(ctx)
+	}()
+
+	select {
+	case err = <-res:
+	case <-ctx.Done():
+		p.abandon(don)
+		err = tracer.Mask(ctx.Err())
+	}
+
+	return timeout(ctx, err)
+}
+
+// abandon tracks the given done channel of an abandoned execution, which gets
+// closed once the abandoned execution finally returned, so that subsequent
+// executions can wait for it.
+func (p *Proxy) abandon(don chan struct{}) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	p.abn = don
+}
+
+// wait blocks until the last abandoned execution of the wrapped worker handler
+// returned, if any. The returned error is the error of the given context, in
+// case the given context is done before the abandoned execution returned.
+func (p *Proxy) wait(ctx context.Context) error {
+	p.mut.Lock()
+	abn := p.abn
+	p.mut.Unlock()
+
+	if abn == nil {
+		return nil
+	}
+
+	select {
+	case <-abn:
+	case <-ctx.Done():
+		return tracer.Mask(ctx.Err())
+	}
+
+	p.mut.Lock()
+	if p.abn == abn {
+		p.abn = nil
+	}
+	p.mut.Unlock()
+
+	return nil
+}
+
+// timeout returns handler.TimeoutError if the given error was caused by the
+// deadline of the given context. Errors of the wrapped worker handler that
+// merely wrap context.DeadlineExceeded, e.g. because of a downstream RPC
+// timeout, are returned as is.
+func timeout(ctx context.Context, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && errors.Is(err, context.DeadlineExceeded) {
+		return tracer.Mask(handler.TimeoutError)
+	}
+
+	return err
+}
+
+// ensure executes the wrapped worker handler and recovers any panic that
+// occurs during its execution. Recovering panics here is important, because
+// the wrapped worker handler may be executed within its own goroutine, in which
+// case no caller could ever recover the panic.
+func (p *Proxy) ensure(ctx context.Context) (err error) {
+	defer func() {
+		rec := recover()
+		if rec != nil {
+			err = handler.Recovered(rec)
+		}
+	}()
+
+	v, i := p.han.(handler.EnsureContext)
+	if i {
+		return v.EnsureContext(ctx)
+	}
+
+	return p.han.Ensure()
+}
@@ -1,5 +1,319 @@
 package proxy
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/tracer"
+)
+
+func Test_Handler_Proxy_EnsureContext(t *testing.T) {
+	testCases := []struct {
+		han handler.Ensure
+		ctx bool
+	}{
+		// Case 000, handler.EnsureContext not implemented
+		{
+			han: &testEnsure{},
+			ctx: false,
+		},
+		// Case 001, handler.EnsureContext implemented
+		{
+			han: &testContext{},
+			ctx: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var pro handler.Interface
+			{
+				pro = New(Config{
+					Han: tc.han,
+				})
+			}
+
+			var ctx context.Context
+			{
+				ctx = context.WithValue(context.Background(), testKey{}, true)
+			}
+
+			var err error
+			{
+				err = pro.EnsureContext(ctx)
+				if err != nil {
+					t.Fatal("expected", nil, "got", err)
+				}
+			}
+
+			var act bool
+			if v, i := tc.han.(*testContext); i {
+				act = v.ctx
+			}
+
+			if dif := cmp.Diff(tc.ctx, act); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+func Test_Handler_Proxy_EnsureContext_timeout(t *testing.T) {
+	var blo chan struct{}
+	{
+		blo = make(chan struct{})
+	}
+
+	{
+		defer close(blo)
+	}
+
+	var pro handler.Interface
+	{
+		pro = New(Config{
+			Han: &testBlock{blo: blo},
+		})
+	}
+
+	var ctx context.Context
+	var can context.CancelFunc
+	{
+		ctx, can = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	}
+
+	{
+		defer can()
+	}
+
+	var err error
+	{
+		err = pro.EnsureContext(ctx)
+	}
+
+	if !handler.IsTimeout(err) {
+		t.Fatal("expected", handler.TimeoutError, "got", err)
+	}
+}
+
+// Test_Handler_Proxy_EnsureContext_abandoned verifies that executions following
+// an abandoned execution wait for the abandoned execution to return, so that
+// the wrapped worker handler is never executed concurrently.
+func Test_Handler_Proxy_EnsureContext_abandoned(t *testing.T) {
+	var blo chan struct{}
+	{
+		blo = make(chan struct{})
+	}
+
+	var han *testCount
+	{
+		han = &testCount{blo: blo}
+	}
+
+	var pro handler.Interface
+	{
+		pro = New(Config{
+			Han: han,
+		})
+	}
+
+	// The first execution gets abandoned, because it blocks until released.
+
+	{
+		ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := pro.EnsureContext(ctx)
+		can()
+		if !handler.IsTimeout(err) {
+			t.Fatal("expected", handler.TimeoutError, "got", err)
+		}
+	}
+
+	// The second execution times out as well, without ever executing the
+	// wrapped worker handler, because the first execution is still running.
+
+	{
+		ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := pro.EnsureContext(ctx)
+		can()
+		if !handler.IsTimeout(err) {
+			t.Fatal("expected", handler.TimeoutError, "got", err)
+		}
+	}
+
+	if dif := cmp.Diff(int32(1), han.cou.Load()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	// The third execution proceeds once the first execution returned.
+
+	{
+		close(blo)
+	}
+
+	{
+		ctx, can := context.WithTimeout(context.Background(), time.Second)
+		err := pro.EnsureContext(ctx)
+		can()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if dif := cmp.Diff(int32(2), han.cou.Load()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
+// Test_Handler_Proxy_EnsureContext_deadline verifies that deadline errors of
+// the wrapped worker handler are not mistaken for handler.TimeoutError, unless
+// the deadline of the execution context got exceeded.
+func Test_Handler_Proxy_EnsureContext_deadline(t *testing.T) {
+	var pro handler.Interface
+	{
+		pro = New(Config{
+			Han: &testDeadline{},
+		})
+	}
+
+	var ctx context.Context
+	var can context.CancelFunc
+	{
+		ctx, can = context.WithTimeout(context.Background(), time.Second)
+	}
+
+	{
+		defer can()
+	}
+
+	var err error
+	{
+		err = pro.EnsureContext(ctx)
+	}
+
+	if handler.IsTimeout(err) {
+		t.Fatal("expected", context.DeadlineExceeded, "got", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected", context.DeadlineExceeded, "got", err)
+	}
+}
+
+func Test_Handler_Proxy_EnsureContext_panic(t *testing.T) {
+	var pro handler.Interface
+	{
+		pro = New(Config{
+			Han: &testPanic{},
+		})
+	}
+
+	var ctx context.Context
+	var can context.CancelFunc
+	{
+		ctx, can = context.WithCancel(context.Background())
+	}
+
+	{
+		defer can()
+	}
+
+	// Verify panic recovery for synchronous as well as for asynchronous
+	// executions, depending on whether the given context can be done.
+
+	for _, x := range []context.Context{context.Background(), ctx} {
+		var err error
+		{
+			err = pro.EnsureContext(x)
+		}
+
+		if !handler.IsPanic(err) {
+			t.Fatal("expected", handler.PanicError, "got", err)
+		}
+		if !strings.Contains(tracer.Json(err), `{"key":"panic","value":"test panic"}`) {
+			t.Fatal("expected", true, "got", false)
+		}
+		if !strings.Contains(tracer.Json(err), `{"key":"stack","value":"goroutine`) {
+			t.Fatal("expected", true, "got", false)
+		}
+	}
+}
+
+type testBlock struct {
+	blo chan struct{}
+}
+
+func (t *testBlock) Active() bool {
+	return true
+}
+
+// Ensure blocks until the underlying channel got closed, regardless of any
+// execution context, in order to simulate a hanging worker handler.
+func (t *testBlock) Ensure() error {
+	<-t.blo
+	return nil
+}
+
+// testCount counts its executions and blocks until the underlying channel got
+// closed, regardless of any execution context.
+type testCount struct {
+	blo chan struct{}
+	cou atomic.Int32
+}
+
+func (t *testCount) Active() bool {
+	return true
+}
+
+func (t *testCount) Ensure() error {
+	t.cou.Add(1)
+	<-t.blo
+	return nil
+}
+
+// testDeadline returns context.DeadlineExceeded right away, like e.g. a timed
+// out downstream RPC would.
+type testDeadline struct{}
+
+func (t *testDeadline) Active() bool {
+	return true
+}
+
+func (t *testDeadline) Ensure() error {
+	return fmt.Errorf("downstream call failed: %w", context.DeadlineExceeded)
+}
+
+type testPanic struct{}
+
+func (t *testPanic) Active() bool {
+	return true
+}
+
+func (t *testPanic) Ensure() error {
+	panic("test panic")
+}
+
+type testContext struct {
+	ctx bool
+}
+
+func (t *testContext) Active() bool {
+	return true
+}
+
+func (t *testContext) Ensure() error {
+	return nil
+}
+
+func (t *testContext) EnsureContext(ctx context.Context) error {
+	t.ctx, _ = ctx.Value(testKey{}).(bool)
+	return nil
+}
+
 type testEnsure struct{}
 
 func (t *testEnsure) Active() bool {
@@ -9,3 +323,5 @@ func (t *testEnsure) Active() bool {
 func (t *testEnsure) Ensure() error {
 	return nil
 }
+
+type testKey struct{}
@@ -0,0 +1,15 @@
+package proxy
+
+import "github.com/0xSplits/workit/handler"
+
+// Group returns the resource group of the underlying worker handler if that
+// handler implements the handler.Group interface. Otherwise an empty string is
+// returned.
+func (p *Proxy) Group() string {
+	v, i := p.han.(handler.Group)
+	if i {
+		return v.Group()
+	}
+
+	return ""
+}
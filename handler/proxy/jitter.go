@@ -0,0 +1,14 @@
+package proxy
+
+import "github.com/0xSplits/workit/handler"
+
+// Jitter returns the jitter fraction of the underlying worker handler if that
+// handler implements the handler.Jitter interface. Otherwise 0 is returned.
+func (p *Proxy) Jitter() float64 {
+	v, i := p.han.(handler.Jitter)
+	if i {
+		return v.Jitter()
+	}
+
+	return 0
+}
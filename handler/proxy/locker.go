@@ -0,0 +1,17 @@
+package proxy
+
+import (
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/locker"
+)
+
+// Locker returns the locker of the underlying worker handler if that handler
+// implements the handler.Locker interface. Otherwise nil is returned.
+func (p *Proxy) Locker() locker.Interface {
+	v, i := p.han.(handler.Locker)
+	if i {
+		return v.Locker()
+	}
+
+	return nil
+}
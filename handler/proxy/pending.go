@@ -0,0 +1,19 @@
+package proxy
+
+// Pending returns a channel that is closed once the last abandoned execution of
+// the wrapped worker handler returned, so that worker engines can hold on to
+// the concurrency limits of abandoned executions. The returned channel is
+// closed right away if there is no abandoned execution.
+func (p *Proxy) Pending() <-chan struct{} {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.abn != nil {
+		return p.abn
+	}
+
+	don := make(chan struct{})
+	close(don)
+
+	return don
+}
@@ -0,0 +1,62 @@
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+)
+
+// Test_Handler_Proxy_Pending verifies that the pending channel of the proxy is
+// only closed once the abandoned execution of the wrapped worker handler
+// returned.
+func Test_Handler_Proxy_Pending(t *testing.T) {
+	var blo chan struct{}
+	{
+		blo = make(chan struct{})
+	}
+
+	var pro *Proxy
+	{
+		pro = New(Config{
+			Han: &testBlock{blo: blo},
+		})
+	}
+
+	select {
+	case <-pro.Pending():
+	default:
+		t.Fatal("expected", "closed channel", "got", "open channel")
+	}
+
+	{
+		ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := pro.EnsureContext(ctx)
+		can()
+		if !handler.IsTimeout(err) {
+			t.Fatal("expected", handler.TimeoutError, "got", err)
+		}
+	}
+
+	var pen <-chan struct{}
+	{
+		pen = pro.Pending()
+	}
+
+	select {
+	case <-pen:
+		t.Fatal("expected", "open channel", "got", "closed channel")
+	default:
+	}
+
+	{
+		close(blo)
+	}
+
+	select {
+	case <-pen:
+	case <-time.After(time.Second):
+		t.Fatal("expected", "closed channel", "got", "open channel")
+	}
+}
@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/0xSplits/workit/handler"
 	"github.com/xh3b4sd/tracer"
@@ -12,12 +13,14 @@ type Config struct {
 }
 
 // Proxy is a handler implementation to resolve optional implementations of
-// handler.Cooler and handler.Unwrap. A worker engine may wrap its configured
-// worker handlers within this proxy implementation in order to cover interface
-// requirements for cases where those functions may not be implemented by the
-// wrapped handlers.
+// handler.Cooler, handler.EnsureContext and handler.Unwrap. A worker engine may
+// wrap its configured worker handlers within this proxy implementation in order
+// to cover interface requirements for cases where those functions may not be
+// implemented by the wrapped handlers.
 type Proxy struct {
+	abn chan struct{}
 	han handler.Ensure
+	mut sync.Mutex
 }
 
 func New(c Config) *Proxy {
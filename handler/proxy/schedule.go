@@ -0,0 +1,18 @@
+package proxy
+
+import (
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/schedule"
+)
+
+// Schedule returns the strict schedule of the underlying worker handler if
+// that handler implements the handler.Schedule interface. Otherwise nil is
+// returned.
+func (p *Proxy) Schedule() schedule.Interface {
+	v, i := p.han.(handler.Schedule)
+	if i {
+		return v.Schedule()
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package proxy
+
+import (
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+)
+
+// Timeout returns the execution timeout of the underlying worker handler if
+// that handler implements the handler.Timeout interface. Otherwise 0 is
+// returned.
+func (p *Proxy) Timeout() time.Duration {
+	v, i := p.han.(handler.Timeout)
+	if i {
+		return v.Timeout()
+	}
+
+	return 0
+}
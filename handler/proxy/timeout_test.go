@@ -0,0 +1,64 @@
+package proxy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Handler_Proxy_Timeout(t *testing.T) {
+	testCases := []struct {
+		han handler.Ensure
+		tim time.Duration
+	}{
+		// Case 000, handler.Timeout not implemented
+		{
+			han: &testEnsure{},
+			tim: 0,
+		},
+		// Case 001, handler.Timeout implemented
+		{
+			han: &testTimeout{tim: 3},
+			tim: 3,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var pro handler.Interface
+			{
+				pro = New(Config{
+					Han: tc.han,
+				})
+			}
+
+			var tim time.Duration
+			{
+				tim = pro.Timeout()
+			}
+
+			if dif := cmp.Diff(tc.tim, tim); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+type testTimeout struct {
+	tim time.Duration
+}
+
+func (t *testTimeout) Active() bool {
+	return true
+}
+
+func (t *testTimeout) Ensure() error {
+	return nil
+}
+
+func (t *testTimeout) Timeout() time.Duration {
+	return t.tim
+}
@@ -0,0 +1,9 @@
+package proxy
+
+import "github.com/0xSplits/workit/handler"
+
+// Wrapped returns the handler directly wrapped by this proxy handler, so that
+// the full wrapper stack can be inspected via handler.Stack.
+func (p *Proxy) Wrapped() handler.Ensure {
+	return p.han
+}
@@ -0,0 +1,30 @@
+package ratelimit
+
+// Active returns false if the wrapped handler implementation would be skipped
+// because no token is available right now, so that worker engines do not
+// execute it in the first place. Otherwise Active forwards the scheduler
+// primitive of the wrapped handler implementation. Note that Active consumes
+// the token of the next execution if the configured policy is PolicySkip, so
+// that wrappers sharing the same bucket cannot take it away before
+// EnsureContext is executed. A token consumed this way is kept for the next
+// execution, so that repeated calls to Active consume at most one token.
+func (r *Ratelimit) Active() bool {
+	if !r.Interface.Active() {
+		return false
+	}
+
+	if r.pol != PolicySkip || r.res.Load() {
+		return true
+	}
+
+	if r.buc.take() != 0 {
+		r.insThr()
+		return false
+	}
+
+	{
+		r.res.Store(true)
+	}
+
+	return true
+}
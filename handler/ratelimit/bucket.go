@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+type BucketConfig struct {
+	// Bur is the optional maximum amount of tokens that the bucket can hold,
+	// which is the maximum amount of executions permitted in a single burst.
+	// Defaults to Lim.
+	Bur int
+
+	// Dur is the interval in which Lim tokens are refilled, e.g. 1 minute for a
+	// third-party API quota of Lim requests per minute.
+	Dur time.Duration
+
+	// Lim is the amount of tokens refilled within Dur. Tokens are refilled
+	// continuously, so that the permitted executions are spread across Dur.
+	Lim int
+}
+
+// Bucket is a token bucket shared between all rate limit wrappers using it, so
+// that multiple worker handlers consuming the same external quota can be
+// limited together, regardless of the worker engine executing them. Every
+// execution of a wrapped worker handler consumes a single token. The bucket
+// starts full.
+type Bucket struct {
+	bur float64
+	las time.Time
+	mut sync.Mutex
+	now func() time.Time
+	rat float64
+	tok float64
+}
+
+func NewBucket(c BucketConfig) *Bucket {
+	if c.Dur <= 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Dur must be positive", c)))
+	}
+	if c.Lim <= 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Lim must be positive", c)))
+	}
+	if c.Bur == 0 {
+		c.Bur = c.Lim
+	}
+	if c.Bur < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Bur must not be negative", c)))
+	}
+
+	return &Bucket{
+		bur: float64(c.Bur),
+		now: time.Now,
+		rat: float64(c.Lim) / float64(c.Dur),
+		tok: float64(c.Bur),
+	}
+}
+
+// allow returns whether a token is available right now, without consuming it.
+func (b *Bucket) allow() bool {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	{
+		b.refill()
+	}
+
+	return b.tok >= 1
+}
+
+// take consumes a single token if available, and returns zero in that case.
+// Otherwise no token is consumed, and the duration until the next token becomes
+// available is returned.
+func (b *Bucket) take() time.Duration {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	{
+		b.refill()
+	}
+
+	if b.tok >= 1 {
+		b.tok--
+		return 0
+	}
+
+	// Round up, so that waiting for the returned duration makes the next token
+	// available.
+
+	return time.Duration(math.Ceil((1 - b.tok) / b.rat))
+}
+
+// refill adds all tokens accumulated since the last refill, up to the burst
+// size of the bucket. Note that the caller must hold the mutex.
+func (b *Bucket) refill() {
+	var now time.Time
+	{
+		now = b.now()
+	}
+
+	if !b.las.IsZero() {
+		b.tok = min(b.bur, b.tok+float64(now.Sub(b.las))*b.rat)
+	}
+
+	{
+		b.las = now
+	}
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Handler_Ratelimit_Bucket_take(t *testing.T) {
+	testCases := []struct {
+		con BucketConfig
+		stp []testTake
+	}{
+		// Case 000, a full bucket permits a burst of Lim executions
+		{
+			con: BucketConfig{Dur: time.Second, Lim: 2},
+			stp: []testTake{
+				{del: 0},
+				{del: 0},
+				{del: 500 * time.Millisecond},
+			},
+		},
+		// Case 001, tokens are refilled continuously
+		{
+			con: BucketConfig{Dur: time.Second, Lim: 2},
+			stp: []testTake{
+				{del: 0},
+				{del: 0},
+				{adv: 250 * time.Millisecond, del: 250 * time.Millisecond},
+				{adv: 250 * time.Millisecond, del: 0},
+				{del: 500 * time.Millisecond},
+			},
+		},
+		// Case 002, refilled tokens never exceed the burst size
+		{
+			con: BucketConfig{Bur: 1, Dur: time.Second, Lim: 10},
+			stp: []testTake{
+				{del: 0},
+				{adv: time.Minute, del: 0},
+				{del: 100 * time.Millisecond},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var buc *Bucket
+			{
+				buc = NewBucket(tc.con)
+			}
+
+			var now time.Time
+			{
+				now = time.Now()
+			}
+
+			{
+				buc.now = func() time.Time { return now }
+			}
+
+			for j, x := range tc.stp {
+				{
+					now = now.Add(x.adv)
+				}
+
+				if dif := cmp.Diff(x.del, buc.take()); dif != "" {
+					t.Fatalf("step %d -expected +actual:\n%s", j, dif)
+				}
+			}
+		})
+	}
+}
+
+type testTake struct {
+	adv time.Duration
+	del time.Duration
+}
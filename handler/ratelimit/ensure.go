@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Ensure runs EnsureContext using the background context.
+func (r *Ratelimit) Ensure() error {
+	return r.EnsureContext(context.Background())
+}
+
+// EnsureContext consumes a single token of the configured bucket and executes
+// the wrapped handler implementation. The token consumed by Active is used
+// instead, if any. If no token is available, the execution is either skipped
+// without error, or delayed until the next token becomes available, depending
+// on the configured policy. Delayed executions are abandoned once the given
+// context is done.
+func (r *Ratelimit) EnsureContext(ctx context.Context) error {
+	var thr bool
+
+	for !r.res.CompareAndSwap(true, false) {
+		del := r.buc.take()
+		if del == 0 {
+			break
+		}
+
+		if !thr {
+			r.insThr()
+			thr = true
+		}
+
+		if r.pol == PolicySkip {
+			r.log.Log(
+				"level", "debug",
+				"message", "skipping throttled worker handler",
+				"handler", r.nam,
+			)
+
+			return nil
+		}
+
+		if !r.delay(ctx, del) {
+			return tracer.Mask(ctx.Err())
+		}
+	}
+
+	err := r.Interface.EnsureContext(ctx)
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	return nil
+}
+
+// delay waits for the given duration and returns false if the given context is
+// done before the next token may become available.
+func (r *Ratelimit) delay(ctx context.Context, del time.Duration) bool {
+	var tim *time.Timer
+	{
+		tim = time.NewTimer(del)
+	}
+
+	select {
+	case <-ctx.Done():
+		tim.Stop()
+		return false
+	case <-tim.C:
+		return true
+	}
+}
@@ -0,0 +1,194 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Handler_Ratelimit_Ensure_skip verifies that throttled executions are
+// skipped, and that the same bucket limits all wrappers sharing it.
+func Test_Handler_Ratelimit_Ensure_skip(t *testing.T) {
+	var buc *Bucket
+	{
+		buc = NewBucket(BucketConfig{Dur: time.Hour, Lim: 2})
+	}
+
+	var one *testHandler
+	var two *testHandler
+	{
+		one = &testHandler{}
+		two = &testHandler{}
+	}
+
+	var rlo handler.Interface
+	var rlt handler.Interface
+	{
+		rlo = New(Config{Buc: buc, Han: one, Log: logger.Fake(), Reg: tesReg()})
+		rlt = New(Config{Buc: buc, Han: two, Log: logger.Fake(), Reg: tesReg()})
+	}
+
+	for range 3 {
+		for _, x := range []handler.Interface{rlo, rlt} {
+			err := x.Ensure()
+			if err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+		}
+	}
+
+	if one.cal+two.cal != 2 {
+		t.Fatal("expected", 2, "got", one.cal+two.cal)
+	}
+
+	if rlo.Active() {
+		t.Fatal("expected", false, "got", true)
+	}
+}
+
+// Test_Handler_Ratelimit_Ensure_active verifies that Active consumes the token
+// of the next execution, so that another wrapper sharing the same bucket cannot
+// take it away before the active wrapper gets executed.
+func Test_Handler_Ratelimit_Ensure_active(t *testing.T) {
+	var buc *Bucket
+	{
+		buc = NewBucket(BucketConfig{Dur: time.Hour, Lim: 1})
+	}
+
+	var one *testHandler
+	var two *testHandler
+	{
+		one = &testHandler{}
+		two = &testHandler{}
+	}
+
+	var rlo *Ratelimit
+	var rlt *Ratelimit
+	{
+		rlo = New(Config{Buc: buc, Han: one, Log: logger.Fake(), Reg: tesReg()})
+		rlt = New(Config{Buc: buc, Han: two, Log: logger.Fake(), Reg: tesReg()})
+	}
+
+	// Calling Active repeatedly must not consume more than a single token.
+
+	for range 2 {
+		if !rlo.Active() {
+			t.Fatal("expected", true, "got", false)
+		}
+	}
+
+	if rlt.Active() {
+		t.Fatal("expected", false, "got", true)
+	}
+
+	{
+		err := rlt.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := rlo.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if one.cal != 1 {
+		t.Fatal("expected", 1, "got", one.cal)
+	}
+
+	if two.cal != 0 {
+		t.Fatal("expected", 0, "got", two.cal)
+	}
+}
+
+// Test_Handler_Ratelimit_Ensure_delay verifies that throttled executions are
+// delayed until the next token becomes available, unless the given context is
+// done before.
+func Test_Handler_Ratelimit_Ensure_delay(t *testing.T) {
+	var buc *Bucket
+	{
+		buc = NewBucket(BucketConfig{Bur: 1, Dur: 20 * time.Millisecond, Lim: 1})
+	}
+
+	var han *testHandler
+	{
+		han = &testHandler{}
+	}
+
+	var rat *Ratelimit
+	{
+		rat = New(Config{Buc: buc, Han: han, Log: logger.Fake(), Pol: PolicyDelay, Reg: tesReg()})
+	}
+
+	var sta time.Time
+	{
+		sta = time.Now()
+	}
+
+	for range 3 {
+		err := rat.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if han.cal != 3 {
+		t.Fatal("expected", 3, "got", han.cal)
+	}
+
+	if time.Since(sta) < 30*time.Millisecond {
+		t.Fatal("expected", "delayed executions", "got", time.Since(sta))
+	}
+
+	{
+		ctx, can := context.WithCancel(context.Background())
+		can()
+
+		err := rat.EnsureContext(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatal("expected", context.Canceled, "got", err)
+		}
+	}
+
+	if han.cal != 3 {
+		t.Fatal("expected", 3, "got", han.cal)
+	}
+}
+
+type testHandler struct {
+	cal int
+}
+
+func (h *testHandler) Active() bool {
+	return true
+}
+
+func (h *testHandler) Cooler() time.Duration {
+	return time.Minute
+}
+
+func (h *testHandler) Ensure() error {
+	h.cal++
+	return nil
+}
+
+func tesReg() *registry.Registry {
+	return registry.New(registry.Config{
+		Env: "testing",
+		Log: logger.Fake(),
+		Met: recorder.NewMeter(recorder.MeterConfig{
+			Env: "testing",
+			Sco: "workit",
+			Ver: "v0.1.0",
+		}),
+	})
+}
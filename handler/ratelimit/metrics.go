@@ -0,0 +1,19 @@
+package ratelimit
+
+import "github.com/xh3b4sd/tracer"
+
+func (r *Ratelimit) insThr() {
+	lab := map[string]string{
+		"handler": r.nam,
+		"policy":  r.pol,
+	}
+
+	err := r.reg.Counter(MetricThrottle, 1, lab)
+	if err != nil {
+		r.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
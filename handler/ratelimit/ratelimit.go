@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/handler/proxy"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	MetricThrottle = "worker_handler_throttle_total"
+)
+
+const (
+	// PolicyDelay waits for the next available token before executing the
+	// wrapped worker handler.
+	PolicyDelay = "delay"
+	// PolicySkip skips the execution of the wrapped worker handler if no token
+	// is available right away.
+	PolicySkip = "skip"
+)
+
+type Config struct {
+	// Buc is the token bucket limiting the executions of the wrapped worker
+	// handler. The same bucket may be shared between multiple rate limit
+	// wrappers, so that all of their worker handlers consume the same quota.
+	Buc *Bucket
+
+	// Han is the worker handler implementing the actual business logic that
+	// should be rate limited.
+	Han handler.Ensure
+
+	// Log is a standard logger interface to forward structured log messages to
+	// any output interface e.g. stdout.
+	Log logger.Interface
+
+	// Pol is the optional policy applied once the wrapped worker handler got
+	// throttled, either PolicyDelay or PolicySkip. Delayed executions count
+	// against the timeout of the wrapped worker handler. Defaults to PolicySkip.
+	Pol string
+
+	// Reg is the metrics registry used to instrument the throttles of the
+	// wrapped worker handler.
+	Reg *registry.Registry
+}
+
+// Ratelimit is a wrapper handler enforcing a token bucket limit on the
+// executions of the wrapped worker handler. Every execution consumes a single
+// token of the configured bucket. Throttled executions are either skipped or
+// delayed, depending on the configured policy. Ratelimit can be used with any
+// worker engine.
+type Ratelimit struct {
+	handler.Forward
+
+	buc *Bucket
+	log logger.Interface
+	nam string
+	pol string
+	reg otelreg.Interface
+	res atomic.Bool
+}
+
+func New(c Config) *Ratelimit {
+	if c.Buc == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Buc must not be empty", c)))
+	}
+	if c.Han == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not be empty", c)))
+	}
+	if c.Log == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
+	}
+	if c.Pol == "" {
+		c.Pol = PolicySkip
+	}
+	if c.Pol != PolicyDelay && c.Pol != PolicySkip {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Pol must be a supported throttle policy", c)))
+	}
+	if c.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
+	}
+
+	// Wrap the given worker handler within a proxy handler, so that we can
+	// forward all optional interfaces of the wrapped worker handler, regardless
+	// of whether they are implemented or not.
+
+	var pro handler.Interface
+	{
+		pro = proxy.New(proxy.Config{
+			Han: c.Han,
+		})
+	}
+
+	var nam string
+	{
+		nam = handler.Name(pro.Unwrap())
+	}
+
+	cou := map[string]registry.Metric{}
+
+	{
+		cou[MetricThrottle] = registry.Metric{
+			Des: "the total amount of throttled worker handler executions",
+			Lab: map[string][]string{
+				"handler": {nam},
+				"policy":  {PolicyDelay, PolicySkip},
+			},
+		}
+	}
+
+	var reg otelreg.Interface
+	{
+		reg = c.Reg.Metrics(cou, map[string]registry.Metric{}, map[string]registry.Metric{})
+	}
+
+	return &Ratelimit{
+		Forward: handler.Forward{Interface: pro},
+
+		buc: c.Buc,
+		log: c.Log,
+		nam: nam,
+		pol: c.Pol,
+		reg: reg,
+	}
+}
@@ -0,0 +1,48 @@
+package retry
+
+import "time"
+
+// Cooler returns the cooler of the wrapped handler implementation as long as
+// its last cycle succeeded. After consecutive failed cycles, Cooler returns an
+// exponentially growing backoff duration with jitter instead, unless the
+// cooler of the wrapped handler implementation is even longer. No jitter is
+// applied if jitter got disabled.
+func (r *Retry) Cooler() time.Duration {
+	var coo time.Duration
+	{
+		coo = r.Interface.Cooler()
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.fai == 0 {
+		return coo
+	}
+
+	// Double the backoff duration for every consecutive failed cycle after the
+	// first one, until we reach the configured upper limit.
+
+	var bac time.Duration
+	{
+		bac = r.bac
+	}
+
+	for i := 1; i < r.fai && bac < r.max; i++ {
+		bac *= 2
+	}
+
+	if bac > r.max {
+		bac = r.max
+	}
+
+	if coo > bac {
+		bac = coo
+	}
+
+	if r.jit == nil {
+		return bac
+	}
+
+	return r.jit.Percent(bac)
+}
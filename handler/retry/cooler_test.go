@@ -0,0 +1,95 @@
+package retry
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Handler_Retry_Cooler(t *testing.T) {
+	testCases := []struct {
+		fai int
+		jit float64
+		ran [2]time.Duration
+	}{
+		// Case 000, no failed cycles, cooler of the wrapped handler
+		{
+			fai: 0,
+			ran: [2]time.Duration{time.Minute, time.Minute},
+		},
+		// Case 001, first failed cycle, cooler of the wrapped handler exceeds
+		// the backoff duration
+		{
+			fai: 1,
+			ran: [2]time.Duration{54 * time.Second, 66 * time.Second},
+		},
+		// Case 002, backoff doubled three times
+		{
+			fai: 4,
+			ran: [2]time.Duration{144 * time.Second, 176 * time.Second},
+		},
+		// Case 003, backoff capped at the upper limit
+		{
+			fai: 10,
+			ran: [2]time.Duration{270 * time.Second, 330 * time.Second},
+		},
+		// Case 004, backoff doubled three times without jitter
+		{
+			fai: 4,
+			jit: -1,
+			ran: [2]time.Duration{160 * time.Second, 160 * time.Second},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var ret *Retry
+			{
+				ret = New(Config{
+					Bac: 20 * time.Second,
+					Han: &testHandler{fai: tc.fai},
+					Jit: tc.jit,
+					Log: logger.Fake(),
+					Reg: tesReg(nil),
+				})
+			}
+
+			for range tc.fai {
+				_ = ret.Ensure()
+			}
+
+			var coo time.Duration
+			{
+				coo = ret.Cooler()
+			}
+
+			if coo < tc.ran[0] || coo > tc.ran[1] {
+				t.Fatal("expected", tc.ran, "got", coo)
+			}
+		})
+	}
+}
+
+// Test_Handler_Retry_Cooler_reset verifies that the backoff is reset once a
+// cycle succeeds again.
+func Test_Handler_Retry_Cooler_reset(t *testing.T) {
+	var ret *Retry
+	{
+		ret = New(Config{
+			Bac: 20 * time.Second,
+			Han: &testHandler{fai: 5},
+			Log: logger.Fake(),
+			Reg: tesReg(nil),
+		})
+	}
+
+	for range 6 {
+		_ = ret.Ensure()
+	}
+
+	if ret.Cooler() != time.Minute {
+		t.Fatal("expected", time.Minute, "got", ret.Cooler())
+	}
+}
@@ -0,0 +1,106 @@
+package retry
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Ensure runs EnsureContext using the background context.
+func (r *Retry) Ensure() error {
+	return r.EnsureContext(context.Background())
+}
+
+// EnsureContext executes the wrapped handler implementation and retries failed
+// executions up to the configured amount of retries within the same cycle.
+// Filtered errors are returned immediately without being retried. No further
+// retry is executed once the given context is done. The outcome of the cycle
+// determines the backoff applied to the next cooler.
+func (r *Retry) EnsureContext(ctx context.Context) error {
+	var err error
+
+	for i := 0; i <= r.ret; i++ {
+		if i != 0 {
+			r.log.Log(
+				"level", "debug",
+				"message", "retrying worker handler",
+				"handler", r.nam,
+				"retry", strconv.Itoa(i),
+			)
+
+			r.insRet()
+		}
+
+		{
+			err = r.Interface.EnsureContext(ctx)
+		}
+
+		if err == nil || r.wrk.Log(err) {
+			break
+		}
+
+		// Do not retry if there are no retries left, or if the given context is
+		// done while we wait for the next retry.
+
+		if i == r.ret || !r.delay(ctx) {
+			break
+		}
+	}
+
+	// Track the amount of consecutive failed cycles, so that we can backoff the
+	// cooler accordingly. Filtered errors do not count as failures.
+
+	{
+		r.mut.Lock()
+		if err == nil || r.wrk.Log(err) {
+			r.fai = 0
+		} else {
+			r.fai++
+		}
+		r.mut.Unlock()
+	}
+
+	return tracer.Mask(err)
+}
+
+// delay waits for the configured retry delay and returns false if the given
+// context is done before the next retry may be executed.
+func (r *Retry) delay(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if r.del == 0 {
+		return true
+	}
+
+	var tim *time.Timer
+	{
+		tim = time.NewTimer(r.del)
+	}
+
+	select {
+	case <-ctx.Done():
+		tim.Stop()
+		return false
+	case <-tim.C:
+		return true
+	}
+}
+
+func (r *Retry) insRet() {
+	lab := map[string]string{
+		"handler": r.nam,
+	}
+
+	err := r.reg.Counter(MetricRetry, 1, lab)
+	if err != nil {
+		r.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
@@ -0,0 +1,156 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Handler_Retry_Ensure(t *testing.T) {
+	testCases := []struct {
+		fai int
+		ret int
+		cal int
+		err bool
+	}{
+		// Case 000, no failure, no retry
+		{
+			fai: 0,
+			ret: 3,
+			cal: 1,
+			err: false,
+		},
+		// Case 001, two failures, succeeding within retries
+		{
+			fai: 2,
+			ret: 3,
+			cal: 3,
+			err: false,
+		},
+		// Case 002, three failures, exceeding retries
+		{
+			fai: 3,
+			ret: 2,
+			cal: 3,
+			err: true,
+		},
+		// Case 003, failure without retries
+		{
+			fai: 1,
+			ret: 0,
+			cal: 1,
+			err: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var han *testHandler
+			{
+				han = &testHandler{fai: tc.fai}
+			}
+
+			var ret *Retry
+			{
+				ret = New(Config{
+					Han: han,
+					Log: logger.Fake(),
+					Reg: tesReg(nil),
+					Ret: tc.ret,
+				})
+			}
+
+			var err error
+			{
+				err = ret.Ensure()
+			}
+
+			if dif := cmp.Diff(tc.err, err != nil); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(tc.cal, han.cal); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+// Test_Handler_Retry_Ensure_filter verifies that filtered errors are neither
+// retried nor considered failures.
+func Test_Handler_Retry_Ensure_filter(t *testing.T) {
+	var han *testHandler
+	{
+		han = &testHandler{fai: 3}
+	}
+
+	var ret *Retry
+	{
+		ret = New(Config{
+			Han: han,
+			Log: logger.Fake(),
+			Reg: tesReg(func(err error) bool { return err != nil }),
+			Ret: 3,
+		})
+	}
+
+	{
+		err := ret.Ensure()
+		if err == nil {
+			t.Fatal("expected", "error", "got", nil)
+		}
+	}
+
+	if dif := cmp.Diff(1, han.cal); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	if dif := cmp.Diff(time.Minute, ret.Cooler()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
+type testHandler struct {
+	cal int
+	fai int
+}
+
+func (h *testHandler) Active() bool {
+	return true
+}
+
+func (h *testHandler) Cooler() time.Duration {
+	return time.Minute
+}
+
+// Ensure fails for as many times as configured, and succeeds afterwards.
+func (h *testHandler) Ensure() error {
+	{
+		h.cal++
+	}
+
+	if h.cal <= h.fai {
+		return errors.New("test error")
+	}
+
+	return nil
+}
+
+func tesReg(fil func(error) bool) *registry.Registry {
+	return registry.New(registry.Config{
+		Env: "testing",
+		Fil: fil,
+		Log: logger.Fake(),
+		Met: recorder.NewMeter(recorder.MeterConfig{
+			Env: "testing",
+			Sco: "workit",
+			Ver: "v0.1.0",
+		}),
+	})
+}
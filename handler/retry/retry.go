@@ -0,0 +1,160 @@
+package retry
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/handler/proxy"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/choreo/jitter"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	MetricRetry = "worker_handler_retry_total"
+)
+
+type Config struct {
+	// Bac is the optional backoff duration applied to the cooler of the wrapped
+	// worker handler after its first failed cycle. The backoff duration doubles
+	// with every consecutive failed cycle, until it reaches Max. Defaults to 1
+	// second.
+	Bac time.Duration
+
+	// Del is the optional delay between retries within a single cycle. No delay
+	// is applied by default.
+	Del time.Duration
+
+	// Han is the worker handler implementing the actual business logic that
+	// should be retried on failure.
+	Han handler.Ensure
+
+	// Jit is the optional fraction of random jitter applied to the backoff
+	// duration, e.g. 0.1 for +-10%. Must be below 1. A negative fraction
+	// disables jitter. Defaults to 0.1.
+	Jit float64
+
+	// Log is a standard logger interface to forward structured log messages to
+	// any output interface e.g. stdout.
+	Log logger.Interface
+
+	// Max is the optional upper limit of the backoff duration applied to the
+	// cooler of the wrapped worker handler. Defaults to 5 minutes.
+	Max time.Duration
+
+	// Reg is the metrics registry used to instrument the retries of the wrapped
+	// worker handler. The error filter of this registry is respected too, so that
+	// filtered errors are neither retried nor considered failures.
+	Reg *registry.Registry
+
+	// Ret is the optional amount of retries within a single cycle, before a
+	// failed cycle is reported to the calling worker engine. No retries are
+	// executed by default, so that only the cooler backoff applies.
+	Ret int
+}
+
+// Retry is a wrapper handler that retries failed executions of the wrapped
+// worker handler within the same cycle, and that applies an exponential backoff
+// with jitter to the cooler of the wrapped worker handler after consecutive
+// failed cycles. The backoff is reset once a cycle succeeds again. Retry can be
+// used with any worker engine. Note that the timeout of the wrapped worker
+// handler applies to the entire cycle, including all of its retries, and that
+// the cooler backoff does not apply to worker handlers executed on a strict
+// schedule.
+type Retry struct {
+	handler.Forward
+
+	bac time.Duration
+	del time.Duration
+	fai int
+	jit *jitter.Jitter[time.Duration]
+	log logger.Interface
+	max time.Duration
+	mut sync.Mutex
+	nam string
+	reg otelreg.Interface
+	ret int
+	wrk *registry.Registry
+}
+
+func New(c Config) *Retry {
+	if c.Bac == 0 {
+		c.Bac = time.Second
+	}
+	if c.Han == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not be empty", c)))
+	}
+	if c.Jit == 0 {
+		c.Jit = 0.1
+	}
+	if c.Jit >= 1 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Jit must be below 1", c)))
+	}
+	if c.Log == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
+	}
+	if c.Max == 0 {
+		c.Max = 5 * time.Minute
+	}
+	if c.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
+	}
+	if c.Ret < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Ret must not be negative", c)))
+	}
+
+	// Wrap the given worker handler within a proxy handler, so that we can
+	// forward all optional interfaces of the wrapped worker handler, regardless
+	// of whether they are implemented or not.
+
+	var pro handler.Interface
+	{
+		pro = proxy.New(proxy.Config{
+			Han: c.Han,
+		})
+	}
+
+	var nam string
+	{
+		nam = handler.Name(pro.Unwrap())
+	}
+
+	cou := map[string]registry.Metric{}
+
+	{
+		cou[MetricRetry] = registry.Metric{
+			Des: "the total amount of worker handler retries within cycles",
+			Lab: map[string][]string{
+				"handler": {nam},
+			},
+		}
+	}
+
+	var reg otelreg.Interface
+	{
+		reg = c.Reg.Metrics(cou, map[string]registry.Metric{}, map[string]registry.Metric{})
+	}
+
+	var jit *jitter.Jitter[time.Duration]
+	if c.Jit > 0 {
+		jit = jitter.New[time.Duration](jitter.Config{Per: c.Jit})
+	}
+
+	return &Retry{
+		Forward: handler.Forward{Interface: pro},
+
+		bac: c.Bac,
+		del: c.Del,
+		jit: jit,
+		log: c.Log,
+		max: c.Max,
+		nam: nam,
+		reg: reg,
+		ret: c.Ret,
+		wrk: c.Reg,
+	}
+}
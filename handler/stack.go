@@ -0,0 +1,37 @@
+package handler
+
+// Stack returns the full wrapper stack of the given worker handler, starting
+// with the given handler itself, and ending with the underlying handler
+// implementation. Every wrapper implementing Wrapper is descended into. Any
+// wrapper that does not implement Wrapper is followed by the handler resolved
+// by its Unwrap, if any. Stack is mostly useful for debugging, e.g. in
+// combination with Names.
+//
+//	metrics -> retry -> proxy -> artefact
+func Stack(han Ensure) []Ensure {
+	var lis []Ensure
+
+	for han != nil {
+		{
+			lis = append(lis, han)
+		}
+
+		w, i := han.(Wrapper)
+		if i {
+			han = w.Wrapped()
+			continue
+		}
+
+		// Worker handlers that do not wrap any other worker handler may unwrap to
+		// themselves, e.g. *handler.Func, which must not be listed twice.
+
+		u, i := han.(Unwrap)
+		if i && u.Unwrap() != nil && u.Unwrap() != han {
+			lis = append(lis, u.Unwrap())
+		}
+
+		break
+	}
+
+	return lis
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Handler_Stack(t *testing.T) {
+	var inn *stackHandler
+	var slf *stackHandler
+	{
+		inn = &stackHandler{}
+		slf = &stackHandler{}
+	}
+
+	{
+		inn.han = inn
+		slf.han = slf
+	}
+
+	testCases := []struct {
+		han Ensure
+		len int
+	}{
+		// Case 000, handlers unwrapping to themselves are listed once
+		{
+			han: slf,
+			len: 1,
+		},
+		// Case 001, handlers unwrapping to another handler of the same type are
+		// listed twice
+		{
+			han: &stackHandler{han: inn},
+			len: 2,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			if dif := cmp.Diff(tc.len, len(Stack(tc.han))); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+type stackHandler struct {
+	han Ensure
+}
+
+func (h *stackHandler) Active() bool {
+	return true
+}
+
+func (h *stackHandler) Ensure() error {
+	return nil
+}
+
+func (h *stackHandler) Unwrap() Ensure {
+	return h.han
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Bud is the optional staleness budget granted to every worker handler. A
+	// worker handler is considered stale once it did not complete another cycle
+	// within its expected cooler plus this staleness budget, or once its
+	// in-flight execution exceeded its execution timeout plus this staleness
+	// budget. In-flight executions without timeout are considered stale once
+	// they exceeded their expected cooler plus this staleness budget. Defaults
+	// to 5 minutes.
+	Bud time.Duration
+}
+
+// Health tracks the execution state of worker handlers, so that their health
+// can be exposed via HTTP, e.g. for Kubernetes liveness and readiness probes.
+// Worker engines record the execution state of their worker handlers if a
+// Health instance is injected into them. The same Health instance may be
+// shared between multiple worker engines.
+type Health struct {
+	bud time.Duration
+	han map[string]*state
+	mut sync.Mutex
+	now func() time.Time
+}
+
+type state struct {
+	coo time.Time
+	dur time.Duration
+	err error
+	fai int
+	fin time.Time
+	reg time.Time
+	run bool
+	sta time.Time
+	suc time.Time
+	tim time.Duration
+}
+
+func New(c Config) *Health {
+	if c.Bud == 0 {
+		c.Bud = 5 * time.Minute
+	}
+	if c.Bud < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Bud must not be negative", c)))
+	}
+
+	return &Health{
+		bud: c.Bud,
+		han: map[string]*state{},
+		now: time.Now,
+	}
+}
@@ -0,0 +1,74 @@
+package health
+
+import "time"
+
+// Register starts tracking the worker handler with the given name. Worker
+// handlers that never completed a cycle are considered stale once the
+// staleness budget expired after registration.
+func (h *Health) Register(nam string) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	h.state(nam)
+}
+
+// Started records the start of a worker handler execution with the given
+// effective execution timeout. A zero or negative timeout means that the
+// execution is not bounded.
+func (h *Health) Started(nam string, tim time.Duration) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	s := h.state(nam)
+
+	s.run = true
+	s.sta = h.now()
+	s.tim = tim
+}
+
+// Finished records the end of a worker handler execution. A non-nil error
+// counts as failure, while a nil error counts as success.
+func (h *Health) Finished(nam string, err error) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	s := h.state(nam)
+
+	s.run = false
+	s.fin = h.now()
+
+	if err != nil {
+		s.err = err
+		s.fai++
+	} else {
+		s.fai = 0
+		s.suc = s.fin
+	}
+}
+
+// Cooling records that the worker handler got scheduled to be executed after
+// the given cooler duration, e.g. after completing its last cycle. Worker
+// handlers that skipped their execution, e.g. because they are inactive, still
+// complete their cycle.
+func (h *Health) Cooling(nam string, coo time.Duration) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	s := h.state(nam)
+
+	s.coo = h.now()
+	s.dur = coo
+}
+
+// state returns the tracked state of the worker handler with the given name,
+// and starts tracking it if necessary. Note that the caller must hold the
+// mutex.
+func (h *Health) state(nam string) *state {
+	s, e := h.han[nam]
+	if !e {
+		s = &state{reg: h.now()}
+		h.han[nam] = s
+	}
+
+	return s
+}
@@ -0,0 +1,114 @@
+package health
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Report is the health snapshot of all tracked worker handlers.
+type Report struct {
+	// Healthy is true if none of the tracked worker handlers is stale.
+	Healthy bool
+
+	// Status is the list of all tracked worker handlers, sorted by name.
+	Status []Status
+}
+
+// Status is the health snapshot of a single worker handler.
+type Status struct {
+	// Cooler is the expected cooler duration after the last completed cycle.
+	Cooler time.Duration
+
+	// Failures is the amount of consecutive failed executions.
+	Failures int
+
+	// LastError is the error of the last failed execution, if any.
+	LastError error
+
+	// LastStart is the start time of the last execution, if any.
+	LastStart time.Time
+
+	// LastSuccess is the end time of the last successful execution, if any.
+	LastSuccess time.Time
+
+	// Name is the name of the worker handler.
+	Name string
+
+	// Ready is true once the worker handler got scheduled by its worker engine.
+	Ready bool
+
+	// Stale is true if the staleness exceeds the configured staleness budget.
+	Stale bool
+
+	// Staleness is the amount of time that the worker handler is overdue to
+	// complete its next cycle. An in-flight execution is overdue once it exceeded
+	// its execution timeout. In-flight executions without timeout are overdue
+	// once they exceeded the expected cooler after their start.
+	Staleness time.Duration
+}
+
+// Report returns the current health snapshot of all tracked worker handlers.
+func (h *Health) Report() Report {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	var now time.Time
+	{
+		now = h.now()
+	}
+
+	rep := Report{
+		Healthy: true,
+	}
+
+	for k, v := range h.han {
+		// The next cycle of a worker handler is due once its expected cooler
+		// passed after completing the last cycle. Worker handlers that never got
+		// scheduled are due since their registration. In-flight executions are
+		// due once their execution timeout passed, so that long running executions
+		// within their timeout are not considered stale. In-flight executions
+		// without timeout are due once the expected cooler passed after their
+		// start, so that hanging executions become stale eventually.
+
+		var due time.Time
+		if v.run && v.tim > 0 {
+			due = v.sta.Add(v.tim)
+		} else if v.run {
+			due = v.sta.Add(v.dur)
+		} else if !v.coo.IsZero() {
+			due = v.coo.Add(v.dur)
+		} else {
+			due = v.reg
+		}
+
+		var sta time.Duration
+		if now.After(due) {
+			sta = now.Sub(due)
+		}
+
+		s := Status{
+			Cooler:      v.dur,
+			Failures:    v.fai,
+			LastError:   v.err,
+			LastStart:   v.sta,
+			LastSuccess: v.suc,
+			Name:        k,
+			Ready:       !v.coo.IsZero(),
+			Stale:       sta > h.bud,
+			Staleness:   sta,
+		}
+
+		if s.Stale {
+			rep.Healthy = false
+		}
+
+		rep.Status = append(rep.Status, s)
+	}
+
+	slices.SortFunc(rep.Status, func(a Status, b Status) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return rep
+}
@@ -0,0 +1,219 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Health_Report(t *testing.T) {
+	var bas time.Time
+	{
+		bas = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	var tes error
+	{
+		tes = errors.New("test error")
+	}
+
+	testCases := []struct {
+		rec func(h *Health, now *time.Time)
+		rep Report
+	}{
+		// Case 000, registered handler within staleness budget
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Register("foo")
+				*now = now.Add(time.Minute)
+			},
+			rep: Report{
+				Healthy: true,
+				Status: []Status{
+					{Name: "foo", Staleness: time.Minute},
+				},
+			},
+		},
+		// Case 001, registered handler never scheduled
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Register("foo")
+				*now = now.Add(10 * time.Minute)
+			},
+			rep: Report{
+				Healthy: false,
+				Status: []Status{
+					{Name: "foo", Stale: true, Staleness: 10 * time.Minute},
+				},
+			},
+		},
+		// Case 002, successful handler cooling down
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("foo", 0)
+				h.Started("foo", 0)
+				*now = now.Add(time.Second)
+				h.Finished("foo", nil)
+				h.Cooling("foo", time.Hour)
+				*now = now.Add(30 * time.Minute)
+			},
+			rep: Report{
+				Healthy: true,
+				Status: []Status{
+					{
+						Cooler:      time.Hour,
+						LastStart:   bas,
+						LastSuccess: bas.Add(time.Second),
+						Name:        "foo",
+						Ready:       true,
+					},
+				},
+			},
+		},
+		// Case 003, failing handler overdue within staleness budget
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("foo", 0)
+				h.Started("foo", 0)
+				h.Finished("foo", nil)
+				h.Started("foo", 0)
+				h.Finished("foo", tes)
+				h.Started("foo", 0)
+				h.Finished("foo", tes)
+				h.Cooling("foo", time.Minute)
+				*now = now.Add(3 * time.Minute)
+			},
+			rep: Report{
+				Healthy: true,
+				Status: []Status{
+					{
+						Cooler:      time.Minute,
+						Failures:    2,
+						LastError:   tes,
+						LastStart:   bas,
+						LastSuccess: bas,
+						Name:        "foo",
+						Ready:       true,
+						Staleness:   2 * time.Minute,
+					},
+				},
+			},
+		},
+		// Case 004, in-flight handler stuck beyond its timeout and the staleness
+		// budget
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("bar", time.Hour)
+				h.Cooling("foo", 0)
+				h.Started("foo", time.Minute)
+				*now = now.Add(7 * time.Minute)
+			},
+			rep: Report{
+				Healthy: false,
+				Status: []Status{
+					{
+						Cooler: time.Hour,
+						Name:   "bar",
+						Ready:  true,
+					},
+					{
+						LastStart: bas,
+						Name:      "foo",
+						Ready:     true,
+						Stale:     true,
+						Staleness: 6 * time.Minute,
+					},
+				},
+			},
+		},
+		// Case 005, in-flight handler running long within its timeout
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("foo", 0)
+				h.Started("foo", time.Hour)
+				*now = now.Add(30 * time.Minute)
+			},
+			rep: Report{
+				Healthy: true,
+				Status: []Status{
+					{
+						LastStart: bas,
+						Name:      "foo",
+						Ready:     true,
+					},
+				},
+			},
+		},
+		// Case 006, in-flight handler without timeout running long within its
+		// cooler
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("foo", time.Hour)
+				h.Started("foo", -1)
+				*now = now.Add(30 * time.Minute)
+			},
+			rep: Report{
+				Healthy: true,
+				Status: []Status{
+					{
+						Cooler:    time.Hour,
+						LastStart: bas,
+						Name:      "foo",
+						Ready:     true,
+					},
+				},
+			},
+		},
+		// Case 007, in-flight handler without timeout blocking forever
+		{
+			rec: func(h *Health, now *time.Time) {
+				h.Cooling("foo", time.Minute)
+				h.Started("foo", 0)
+				*now = now.Add(24 * time.Hour)
+			},
+			rep: Report{
+				Healthy: false,
+				Status: []Status{
+					{
+						Cooler:    time.Minute,
+						LastStart: bas,
+						Name:      "foo",
+						Ready:     true,
+						Stale:     true,
+						Staleness: 24*time.Hour - time.Minute,
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var now time.Time
+			{
+				now = bas
+			}
+
+			var hea *Health
+			{
+				hea = New(Config{})
+			}
+
+			{
+				hea.now = func() time.Time { return now }
+			}
+
+			{
+				tc.rec(hea, &now)
+			}
+
+			rep := hea.Report()
+			if dif := cmp.Diff(tc.rep, rep, cmp.Comparer(func(a error, b error) bool { return a == b })); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
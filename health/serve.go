@@ -0,0 +1,112 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type response struct {
+	Healthy bool       `json:"healthy"`
+	Ready   bool       `json:"ready"`
+	Status  []resource `json:"handlers"`
+}
+
+type resource struct {
+	Cooler      string    `json:"cooler"`
+	Failures    int       `json:"consecutive_failures"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastStart   time.Time `json:"last_start,omitzero"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	Name        string    `json:"name"`
+	Ready       bool      `json:"ready"`
+	Stale       bool      `json:"stale"`
+	Staleness   string    `json:"staleness"`
+}
+
+// ServeHTTP serves the current health report as JSON, backing e.g. Kubernetes
+// liveness probes. The response status is 503 if any of the tracked worker
+// handlers is stale, and 200 otherwise.
+func (h *Health) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var rep Report
+	{
+		rep = h.Report()
+	}
+
+	var cod int
+	if rep.Healthy {
+		cod = http.StatusOK
+	} else {
+		cod = http.StatusServiceUnavailable
+	}
+
+	{
+		write(w, cod, rep)
+	}
+}
+
+// Ready returns an HTTP handler serving the current health report as JSON,
+// backing e.g. Kubernetes readiness probes. The response status is 503 until
+// all tracked worker handlers got scheduled by their worker engines, and 200
+// afterwards.
+func (h *Health) Ready() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var rep Report
+		{
+			rep = h.Report()
+		}
+
+		var cod int
+		if ready(rep) {
+			cod = http.StatusOK
+		} else {
+			cod = http.StatusServiceUnavailable
+		}
+
+		{
+			write(w, cod, rep)
+		}
+	})
+}
+
+func ready(rep Report) bool {
+	for _, x := range rep.Status {
+		if !x.Ready {
+			return false
+		}
+	}
+
+	return true
+}
+
+func write(w http.ResponseWriter, cod int, rep Report) {
+	res := response{
+		Healthy: rep.Healthy,
+		Ready:   ready(rep),
+		Status:  []resource{},
+	}
+
+	for _, x := range rep.Status {
+		var err string
+		if x.LastError != nil {
+			err = x.LastError.Error()
+		}
+
+		res.Status = append(res.Status, resource{
+			Cooler:      x.Cooler.String(),
+			Failures:    x.Failures,
+			LastError:   err,
+			LastStart:   x.LastStart,
+			LastSuccess: x.LastSuccess,
+			Name:        x.Name,
+			Ready:       x.Ready,
+			Stale:       x.Stale,
+			Staleness:   x.Staleness.String(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(cod)
+
+	_ = json.NewEncoder(w).Encode(res)
+}
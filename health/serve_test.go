@@ -0,0 +1,110 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func Test_Health_ServeHTTP(t *testing.T) {
+	var now time.Time
+	{
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	var hea *Health
+	{
+		hea = New(Config{Bud: time.Minute})
+	}
+
+	{
+		hea.now = func() time.Time { return now }
+	}
+
+	var srv *httptest.Server
+	{
+		rtr := mux.NewRouter()
+		rtr.Handle("/healthz", hea)
+		rtr.Handle("/readyz", hea.Ready())
+		srv = httptest.NewServer(rtr)
+	}
+
+	{
+		defer srv.Close()
+	}
+
+	// Registered worker handlers are neither ready nor stale.
+
+	{
+		hea.Register("foo")
+	}
+
+	{
+		tesCod(t, srv.URL+"/healthz", http.StatusOK)
+		tesCod(t, srv.URL+"/readyz", http.StatusServiceUnavailable)
+	}
+
+	// Scheduled worker handlers are ready.
+
+	{
+		hea.Cooling("foo", time.Minute)
+	}
+
+	{
+		tesCod(t, srv.URL+"/healthz", http.StatusOK)
+		tesCod(t, srv.URL+"/readyz", http.StatusOK)
+	}
+
+	// Worker handlers exceeding their staleness budget are unhealthy.
+
+	{
+		now = now.Add(3 * time.Minute)
+	}
+
+	var res response
+	{
+		res = tesCod(t, srv.URL+"/healthz", http.StatusServiceUnavailable)
+	}
+
+	if res.Healthy {
+		t.Fatal("expected", false, "got", res.Healthy)
+	}
+	if len(res.Status) != 1 {
+		t.Fatal("expected", 1, "got", len(res.Status))
+	}
+	if res.Status[0].Name != "foo" {
+		t.Fatal("expected", "foo", "got", res.Status[0].Name)
+	}
+	if res.Status[0].Staleness != "2m0s" {
+		t.Fatal("expected", "2m0s", "got", res.Status[0].Staleness)
+	}
+}
+
+func tesCod(t *testing.T, url string, cod int) response {
+	t.Helper()
+
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != cod {
+		t.Fatal("expected", cod, "got", res.StatusCode)
+	}
+
+	var bod response
+	{
+		err := json.NewDecoder(res.Body).Decode(&bod)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return bod
+}
@@ -0,0 +1,88 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xSplits/workit/locker"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Ensure acquires or renews the lease for the given key using the given
+// locker, and returns whether the lease is held. A lease that cannot be renewed
+// is considered lost, regardless of whether the given locker returned an error
+// or not. Once Leader.Release got called, no lease is acquired anymore.
+func (l *Leader) Ensure(ctx context.Context, loc locker.Interface, key string) (bool, error) {
+	// Prevent Leader.Release from giving up the leases while any lease is being
+	// acquired, so that no lease is acquired after it got released.
+
+	l.ens.RLock()
+	defer l.ens.RUnlock()
+
+	if l.clo {
+		return false, nil
+	}
+
+	acq, err := loc.Acquire(ctx, key)
+	if err != nil {
+		acq = false
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	_, hel := l.lea[key]
+
+	if acq && !hel {
+		l.acquired(loc, key)
+	}
+
+	if !acq && hel {
+		l.lost(key)
+	}
+
+	if err != nil {
+		return false, tracer.Mask(err, tracer.Context{Key: "lease", Value: key})
+	}
+
+	return acq, nil
+}
+
+// acquired tracks the newly acquired lease for the given key. Note that the
+// caller must hold the mutex.
+func (l *Leader) acquired(loc locker.Interface, key string) {
+	{
+		l.lea[key] = lease{loc: loc, sta: l.now()}
+	}
+
+	l.log.Log(
+		"level", "info",
+		"message", "worker acquired lease",
+		"lease", key,
+	)
+
+	{
+		l.insCou(MetricAcquired, key)
+		l.insHel(key, 1)
+	}
+}
+
+// lost stops tracking the lease for the given key, because that lease could
+// not be renewed anymore. Note that the caller must hold the mutex.
+func (l *Leader) lost(key string) {
+	var hol time.Duration
+	{
+		hol = l.forget(key)
+	}
+
+	l.log.Log(
+		"level", "warning",
+		"message", "worker lost lease",
+		"lease", key,
+		"hold", hol.String(),
+	)
+
+	{
+		l.insCou(MetricLost, key)
+	}
+}
@@ -0,0 +1,220 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Leader_Ensure(t *testing.T) {
+	testCases := []struct {
+		acq []bool
+		err []error
+		hel []bool
+		log []string
+	}{
+		// Case 000, lease acquired and renewed
+		{
+			acq: []bool{true, true},
+			err: []error{nil, nil},
+			hel: []bool{true, true},
+			log: []string{"worker acquired lease"},
+		},
+		// Case 001, lease held by somebody else
+		{
+			acq: []bool{false, false},
+			err: []error{nil, nil},
+			hel: []bool{false, false},
+			log: nil,
+		},
+		// Case 002, lease acquired and lost
+		{
+			acq: []bool{true, false, true},
+			err: []error{nil, nil, nil},
+			hel: []bool{true, false, true},
+			log: []string{"worker acquired lease", "worker lost lease", "worker acquired lease"},
+		},
+		// Case 003, lease lost due to locker error
+		{
+			acq: []bool{true, true},
+			err: []error{nil, errors.New("test error")},
+			hel: []bool{true, false},
+			log: []string{"worker acquired lease", "worker lost lease"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var buf strings.Builder
+
+			var lea *Leader
+			{
+				lea = New(Config{
+					Key: []string{"foo"},
+					Log: logger.New(logger.Config{
+						Filter: logger.NewLevelFilter("info"),
+						Writer: &buf,
+					}),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+				})
+			}
+
+			var loc *testLocker
+			{
+				loc = &testLocker{acq: tc.acq, err: tc.err}
+			}
+
+			for j := range tc.acq {
+				hel, err := lea.Ensure(context.Background(), loc, "foo")
+				if (err != nil) != (tc.err[j] != nil) {
+					t.Fatal("expected", tc.err[j], "got", err)
+				}
+				if hel != tc.hel[j] {
+					t.Fatal("expected", tc.hel[j], "got", hel)
+				}
+			}
+
+			var log []string
+			for _, x := range strings.Split(buf.String(), "\n") {
+				for _, y := range []string{"worker acquired lease", "worker lost lease"} {
+					if strings.Contains(x, y) {
+						log = append(log, y)
+					}
+				}
+			}
+
+			if dif := cmp.Diff(tc.log, log); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+func Test_Leader_Release(t *testing.T) {
+	var buf strings.Builder
+
+	var lea *Leader
+	{
+		lea = New(Config{
+			Key: []string{"foo", "bar"},
+			Log: logger.New(logger.Config{
+				Filter: logger.NewLevelFilter("info"),
+				Writer: &buf,
+			}),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	var now time.Time
+	{
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	{
+		lea.now = func() time.Time { return now }
+	}
+
+	var loc *testLocker
+	{
+		loc = &testLocker{acq: []bool{true, true}, err: []error{nil, nil}}
+	}
+
+	{
+		_, _ = lea.Ensure(context.Background(), loc, "foo")
+		_, _ = lea.Ensure(context.Background(), loc, "bar")
+	}
+
+	{
+		now = now.Add(90 * time.Second)
+	}
+
+	{
+		err := lea.Release(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if len(loc.rel) != 2 {
+		t.Fatal("expected", 2, "got", len(loc.rel))
+	}
+	if strings.Count(buf.String(), "worker released lease") != 2 {
+		t.Fatal("expected", "released leases", "got", buf.String())
+	}
+	if !strings.Contains(buf.String(), `"hold":"1m30s"`) {
+		t.Fatal("expected", "hold time", "got", buf.String())
+	}
+
+	// Releasing again is a noop, because no lease is held anymore.
+
+	{
+		err := lea.Release(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if len(loc.rel) != 2 {
+		t.Fatal("expected", 2, "got", len(loc.rel))
+	}
+}
+
+// testLocker returns the configured responses one after another, and keeps the
+// lease once it ran out of responses.
+type testLocker struct {
+	acq []bool
+	cal int
+	err []error
+	mut sync.Mutex
+	rel []string
+}
+
+func (l *testLocker) Acquire(ctx context.Context, key string) (bool, error) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	{
+		l.cal++
+	}
+
+	if len(l.acq) == 0 {
+		return true, nil
+	}
+
+	acq, err := l.acq[0], l.err[0]
+	l.acq, l.err = l.acq[1:], l.err[1:]
+	return acq, err
+}
+
+func (l *testLocker) Release(ctx context.Context, key string) error {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.rel = append(l.rel, key)
+	return nil
+}
@@ -0,0 +1,18 @@
+package leader
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// LeaseLostError is returned for worker handler executions that got cancelled,
+// because the lease guarding them could not be renewed during the execution.
+var LeaseLostError = &tracer.Error{
+	Description: "The worker lease got lost while the worker handler was still executing.",
+}
+
+// IsLeaseLost returns true if the given error is or wraps LeaseLostError.
+func IsLeaseLost(err error) bool {
+	return errors.Is(err, LeaseLostError)
+}
@@ -0,0 +1,99 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xSplits/workit/locker"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Hold renews the lease for the given key using the given locker periodically,
+// while the caller executes the work guarded by that lease within the returned
+// context. The returned context is cancelled as soon as the lease cannot be
+// renewed anymore, so that no work continues without holding the lease. The
+// returned function stops renewing the lease, and returns LeaseLostError if the
+// lease got lost in the meantime. Hold is a noop if the given locker is nil.
+// The returned context is cancelled right away once Leader.Release got called.
+func (l *Leader) Hold(ctx context.Context, loc locker.Interface, key string) (context.Context, func() error) {
+	if loc == nil {
+		return ctx, func() error { return nil }
+	}
+
+	var can context.CancelCauseFunc
+	{
+		ctx, can = context.WithCancelCause(ctx)
+	}
+
+	// Track every lease renewal, so that Leader.Release can wait for all of them
+	// to stop. Note that no lease renewal is started once Leader.Release got
+	// called, because the lease cannot be renewed anymore anyway.
+
+	l.ens.RLock()
+	clo := l.clo
+	if !clo {
+		l.hol.Add(1)
+	}
+	l.ens.RUnlock()
+
+	if clo {
+		can(tracer.Mask(LeaseLostError, tracer.Context{Key: "lease", Value: key}))
+
+		return ctx, func() error {
+			return tracer.Mask(context.Cause(ctx))
+		}
+	}
+
+	var don chan struct{}
+	var fin chan struct{}
+	{
+		don = make(chan struct{})
+		fin = make(chan struct{})
+	}
+
+	go func() {
+		defer l.hol.Done()
+		defer close(fin)
+
+		tic := time.NewTicker(l.ren)
+		defer tic.Stop()
+
+		for {
+			select {
+			case <-don:
+				return
+			case <-ctx.Done():
+				return
+			case <-tic.C:
+			}
+
+			acq, err := l.Ensure(ctx, loc, key)
+			if err != nil {
+				l.log.Log(
+					"level", "error",
+					"message", "worker lease renewal failed",
+					"stack", tracer.Json(err),
+				)
+			}
+
+			if !acq {
+				can(tracer.Mask(LeaseLostError, tracer.Context{Key: "lease", Value: key}))
+				return
+			}
+		}
+	}()
+
+	return ctx, func() error {
+		close(don)
+		<-fin
+
+		err := context.Cause(ctx)
+		can(nil)
+
+		if IsLeaseLost(err) {
+			return err
+		}
+
+		return nil
+	}
+}
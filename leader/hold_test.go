@@ -0,0 +1,227 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Leader_Hold(t *testing.T) {
+	testCases := []struct {
+		acq []bool
+		err []error
+		los bool
+	}{
+		// Case 000, lease renewed until the work is done
+		{
+			acq: []bool{true, true, true},
+			err: []error{nil, nil, nil},
+			los: false,
+		},
+		// Case 001, lease lost during the work
+		{
+			acq: []bool{true, true, false},
+			err: []error{nil, nil, nil},
+			los: true,
+		},
+		// Case 002, lease lost due to locker error during the work
+		{
+			acq: []bool{true, true},
+			err: []error{nil, errors.New("test error")},
+			los: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var lea *Leader
+			{
+				lea = New(Config{
+					Key: []string{"foo"},
+					Log: logger.Fake(),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+					Ren: time.Millisecond,
+				})
+			}
+
+			var loc *testLocker
+			{
+				loc = &testLocker{acq: tc.acq, err: tc.err}
+			}
+
+			{
+				_, _ = lea.Ensure(context.Background(), loc, "foo")
+			}
+
+			ctx, hol := lea.Hold(context.Background(), loc, "foo")
+
+			// Wait for the lease to get lost, or for all renewals to happen, while
+			// the test locker keeps the lease once it ran out of responses.
+
+			for {
+				loc.mut.Lock()
+				rem := len(loc.acq)
+				loc.mut.Unlock()
+
+				if rem == 0 || ctx.Err() != nil {
+					break
+				}
+
+				time.Sleep(time.Millisecond)
+			}
+
+			if tc.los {
+				<-ctx.Done()
+			}
+
+			err := hol()
+			if IsLeaseLost(err) != tc.los {
+				t.Fatal("expected", tc.los, "got", err)
+			}
+			if tc.los && !strings.Contains(fmt.Sprint(context.Cause(ctx)), "lease got lost") {
+				t.Fatal("expected", LeaseLostError, "got", context.Cause(ctx))
+			}
+			if !tc.los && ctx.Err() == nil {
+				t.Fatal("expected", "cancelled context", "got", nil)
+			}
+		})
+	}
+}
+
+func Test_Leader_Hold_noop(t *testing.T) {
+	var lea *Leader
+	{
+		lea = New(Config{
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	ctx, hol := lea.Hold(context.Background(), nil, "foo")
+	if ctx != context.Background() {
+		t.Fatal("expected", "given context", "got", ctx)
+	}
+
+	err := hol()
+	if err != nil {
+		t.Fatal("expected", nil, "got", err)
+	}
+}
+
+// Test_Leader_Hold_release verifies that Leader.Release stops all lease
+// renewals, and that no lease is acquired anymore once released.
+func Test_Leader_Hold_release(t *testing.T) {
+	var lea *Leader
+	{
+		lea = New(Config{
+			Key: []string{"foo"},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Ren: time.Millisecond,
+		})
+	}
+
+	var loc *testLocker
+	{
+		loc = &testLocker{}
+	}
+
+	{
+		_, _ = lea.Ensure(context.Background(), loc, "foo")
+	}
+
+	// Release the lease while it is still being renewed. The renewals stop,
+	// which cancels the context of the work guarded by the released lease.
+
+	ctx, hol := lea.Hold(context.Background(), loc, "foo")
+
+	{
+		err := lea.Release(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		<-ctx.Done()
+	}
+
+	{
+		err := hol()
+		if !IsLeaseLost(err) {
+			t.Fatal("expected", LeaseLostError, "got", err)
+		}
+	}
+
+	var cal int
+	{
+		loc.mut.Lock()
+		cal = loc.cal
+		loc.mut.Unlock()
+	}
+
+	// Neither Ensure nor Hold acquire the lease anymore once released.
+
+	{
+		acq, err := lea.Ensure(context.Background(), loc, "foo")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		if acq {
+			t.Fatal("expected", false, "got", true)
+		}
+	}
+
+	{
+		ctx, hol := lea.Hold(context.Background(), loc, "foo")
+		if ctx.Err() == nil {
+			t.Fatal("expected", "cancelled context", "got", nil)
+		}
+		if !IsLeaseLost(hol()) {
+			t.Fatal("expected", LeaseLostError, "got", nil)
+		}
+	}
+
+	{
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	{
+		loc.mut.Lock()
+		if loc.cal != cal {
+			t.Fatal("expected", cal, "got", loc.cal)
+		}
+		loc.mut.Unlock()
+	}
+}
@@ -0,0 +1,132 @@
+package leader
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	MetricAcquired = "worker_lease_acquired_total"
+	MetricHeld     = "worker_lease_held"
+	MetricHold     = "worker_lease_hold_duration_seconds"
+	MetricLost     = "worker_lease_lost_total"
+)
+
+type Config struct {
+	// Key is the list of all lease keys that may be acquired via this leader.
+	// The lease keys are whitelisted as metric labels.
+	Key []string
+
+	// Log is a standard logger interface to forward structured log messages to
+	// any output interface e.g. stdout.
+	Log logger.Interface
+
+	// Reg is the metrics registry used to instrument lease acquisition, lease
+	// loss and lease hold time.
+	Reg *registry.Registry
+
+	// Ren is the optional interval at which leases are renewed while the work
+	// guarded by them is still executing, see Leader.Hold. The interval must be
+	// shorter than the lease duration of the lockers in use. Defaults to 10
+	// seconds.
+	Ren time.Duration
+}
+
+// Leader keeps track of the leases held by a single worker engine. Worker
+// engines consult Leader.Ensure before executing any worker handler guarded by
+// a locker, so that only the replica holding the lease executes the worker
+// handler, and renew the lease via Leader.Hold during the execution. Every
+// lease acquisition and every lease loss is logged and instrumented, including
+// the amount of time that a lease has been held.
+type Leader struct {
+	clo bool
+	ens sync.RWMutex
+	hol sync.WaitGroup
+	lea map[string]lease
+	log logger.Interface
+	mut sync.Mutex
+	now func() time.Time
+	reg otelreg.Interface
+	ren time.Duration
+}
+
+type lease struct {
+	loc locker.Interface
+	sta time.Time
+}
+
+func New(c Config) *Leader {
+	if c.Log == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
+	}
+	if c.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
+	}
+	if c.Ren == 0 {
+		c.Ren = 10 * time.Second
+	}
+	if c.Ren < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Ren must not be negative", c)))
+	}
+
+	var lab map[string][]string
+	{
+		lab = map[string][]string{
+			"lease": c.Key,
+		}
+	}
+
+	cou := map[string]registry.Metric{}
+	gau := map[string]registry.Metric{}
+	his := map[string]registry.Metric{}
+
+	{
+		cou[MetricAcquired] = registry.Metric{
+			Des: "the total amount of acquired worker leases",
+			Lab: lab,
+		}
+		cou[MetricLost] = registry.Metric{
+			Des: "the total amount of lost worker leases",
+			Lab: lab,
+		}
+	}
+
+	{
+		gau[MetricHeld] = registry.Metric{
+			Des: "whether the worker lease is currently held",
+			Lab: lab,
+		}
+	}
+
+	{
+		his[MetricHold] = registry.Metric{
+			Buc: []float64{
+				1,
+				10,
+				60,
+				300,
+				900,
+				3600,
+				21600,
+				86400,
+			},
+			Des: "the amount of time that worker leases were held",
+			Lab: lab,
+		}
+	}
+
+	return &Leader{
+		lea: map[string]lease{},
+		log: c.Log,
+		now: time.Now,
+		reg: c.Reg.Metrics(cou, gau, his),
+		ren: c.Ren,
+	}
+}
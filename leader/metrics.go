@@ -0,0 +1,36 @@
+package leader
+
+import (
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+func (l *Leader) insCou(met string, key string) {
+	err := l.reg.Counter(met, 1, map[string]string{"lease": key})
+	if err != nil {
+		l.error(err)
+	}
+}
+
+func (l *Leader) insHel(key string, val float64) {
+	err := l.reg.Gauge(MetricHeld, val, map[string]string{"lease": key})
+	if err != nil {
+		l.error(err)
+	}
+}
+
+func (l *Leader) insHol(key string, hol time.Duration) {
+	err := l.reg.Histogram(MetricHold, hol.Seconds(), map[string]string{"lease": key})
+	if err != nil {
+		l.error(err)
+	}
+}
+
+func (l *Leader) error(err error) {
+	l.log.Log(
+		"level", "error",
+		"message", "worker instrumentation failed",
+		"stack", tracer.Json(err),
+	)
+}
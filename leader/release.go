@@ -0,0 +1,81 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Release gives up all leases currently held, so that other replicas may take
+// over right away, e.g. once the worker engine stopped. Release tries to give
+// up every lease, even if some locker fails to do so. No lease is acquired
+// anymore once Release got called. Release waits for all lease renewals
+// started via Leader.Hold to stop, which is why the contexts given to
+// Leader.Hold should be cancelled beforehand.
+func (l *Leader) Release(ctx context.Context) error {
+	// Stop acquiring any lease, and wait for all leases currently being acquired,
+	// as well as for all lease renewals, so that no lease can be acquired again
+	// after it got released below.
+
+	{
+		l.ens.Lock()
+		l.clo = true
+		l.ens.Unlock()
+	}
+
+	{
+		l.hol.Wait()
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	var rel []error
+
+	for k, v := range l.lea {
+		err := v.loc.Release(ctx, k)
+		if err != nil {
+			rel = append(rel, tracer.Mask(err, tracer.Context{Key: "lease", Value: k}))
+		}
+
+		var hol time.Duration
+		{
+			hol = l.forget(k)
+		}
+
+		l.log.Log(
+			"level", "info",
+			"message", "worker released lease",
+			"lease", k,
+			"hold", hol.String(),
+		)
+	}
+
+	if len(rel) != 0 {
+		return tracer.Mask(errors.Join(rel...))
+	}
+
+	return nil
+}
+
+// forget stops tracking the lease for the given key and returns the amount of
+// time that the lease has been held. Note that the caller must hold the mutex.
+func (l *Leader) forget(key string) time.Duration {
+	var hol time.Duration
+	{
+		hol = l.now().Sub(l.lea[key].sta)
+	}
+
+	{
+		delete(l.lea, key)
+	}
+
+	{
+		l.insHel(key, 0)
+		l.insHol(key, hol)
+	}
+
+	return hol
+}
@@ -0,0 +1,60 @@
+//go:build unix
+
+package file
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Acquire obtains the lease for the given key by placing an exclusive advisory
+// lock on the respective lock file, without blocking if that lock is held by
+// somebody else already. Leases already held by this locker remain held.
+func (f *File) Acquire(ctx context.Context, key string) (bool, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) {
+		return false, tracer.Mask(leaseKeyInvalidError, tracer.Context{Key: "key", Value: key})
+	}
+
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	{
+		_, e := f.fil[key]
+		if e {
+			return true, nil
+		}
+	}
+
+	var fil *os.File
+	{
+		var err error
+
+		fil, err = os.OpenFile(filepath.Join(f.dir, key+".lock"), os.O_CREATE|os.O_RDWR, 0o600)
+		if err != nil {
+			return false, tracer.Mask(err)
+		}
+	}
+
+	{
+		err := syscall.Flock(int(fil.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			_ = fil.Close()
+			return false, nil
+		} else if err != nil {
+			_ = fil.Close()
+			return false, tracer.Mask(err)
+		}
+	}
+
+	{
+		f.fil[key] = fil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,68 @@
+//go:build unix
+
+package file
+
+import (
+	"context"
+	"testing"
+)
+
+func Test_Locker_File_Acquire(t *testing.T) {
+	var dir string
+	{
+		dir = t.TempDir()
+	}
+
+	// Lock files are locked per open file, so that two lockers within the same
+	// process compete for the same leases like two processes would.
+
+	var one *File
+	var two *File
+	{
+		one = New(Config{Dir: dir})
+		two = New(Config{Dir: dir})
+	}
+
+	{
+		tesAcq(t, one, "foo", true)
+		tesAcq(t, one, "foo", true)
+		tesAcq(t, two, "foo", false)
+		tesAcq(t, two, "bar", true)
+	}
+
+	{
+		err := two.Release(context.Background(), "foo")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesAcq(t, two, "foo", false)
+	}
+
+	{
+		err := one.Release(context.Background(), "foo")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesAcq(t, two, "foo", true)
+		tesAcq(t, one, "foo", false)
+	}
+
+	{
+		_, err := one.Acquire(context.Background(), "../foo")
+		if !isLeaseKeyInvalid(err) {
+			t.Fatal("expected", leaseKeyInvalidError, "got", err)
+		}
+	}
+}
+
+func tesAcq(t *testing.T, loc *File, key string, exp bool) {
+	t.Helper()
+
+	acq, err := loc.Acquire(context.Background(), key)
+	if err != nil {
+		t.Fatal("expected", nil, "got", err)
+	}
+	if acq != exp {
+		t.Fatal("expected", exp, "got", acq)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package file
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+var leaseKeyInvalidError = &tracer.Error{
+	Description: "This error indicates that the given lease key cannot be used as file name, because it is either empty or contains path separators.",
+}
+
+func isLeaseKeyInvalid(err error) bool {
+	return errors.Is(err, leaseKeyInvalidError)
+}
@@ -0,0 +1,50 @@
+//go:build unix
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Dir is the optional directory to create the lock files in. All replicas
+	// competing for the same leases must use the same directory. Defaults to
+	// os.TempDir.
+	Dir string
+}
+
+// File is a file lock based implementation of locker.Interface. Leases are
+// advisory locks on files within the configured directory, which are held for
+// as long as the acquiring process keeps the respective lock file open. Leases
+// of crashed processes are released automatically by the operating system.
+// File is mostly useful for single-host setups.
+type File struct {
+	dir string
+	fil map[string]*os.File
+	mut sync.Mutex
+}
+
+func New(c Config) *File {
+	if c.Dir == "" {
+		c.Dir = os.TempDir()
+	}
+
+	{
+		s, err := os.Stat(c.Dir)
+		if err != nil {
+			tracer.Panic(tracer.Mask(err))
+		}
+		if !s.IsDir() {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Dir must be a directory", c)))
+		}
+	}
+
+	return &File{
+		dir: c.Dir,
+		fil: map[string]*os.File{},
+	}
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package file
+
+import (
+	"context"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Release unlocks and closes the lock file for the given key, if the lease is
+// held by this locker. The lock file itself is kept, so that competing lockers
+// keep locking the very same file.
+func (f *File) Release(ctx context.Context, key string) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+
+	fil, e := f.fil[key]
+	if !e {
+		return nil
+	}
+
+	{
+		delete(f.fil, key)
+	}
+
+	{
+		err := fil.Close() // closing the file releases the advisory lock
+		if err != nil {
+			return tracer.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package locker
+
+import "context"
+
+// Interface describes a distributed lease used for leader election across
+// multiple replicas of the same service. Worker engines only execute worker
+// handlers for which they hold the respective lease, so that only a single
+// replica executes any given worker handler at a time.
+type Interface interface {
+	// Acquire tries to obtain the lease for the given key, or to renew it if the
+	// lease is already held by the caller. Acquire returns true if the caller
+	// holds the lease after the call returned. Acquire must not block while the
+	// lease is held by somebody else.
+	Acquire(ctx context.Context, key string) (bool, error)
+
+	// Release gives up the lease for the given key, so that other replicas may
+	// acquire it right away. Releasing a lease that is not held by the caller is
+	// a noop.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"time"
+)
+
+// Acquire obtains the lease for the given key if the lease is not held by any
+// other owner, or if the lease of the other owner expired already. Acquiring a
+// lease that is already held by this owner renews it.
+func (m *Memory) Acquire(ctx context.Context, key string) (bool, error) {
+	m.sto.mut.Lock()
+	defer m.sto.mut.Unlock()
+
+	if m.sto.lea == nil {
+		m.sto.lea = map[string]lease{}
+	}
+
+	var now time.Time
+	{
+		now = m.now()
+	}
+
+	{
+		l, e := m.sto.lea[key]
+		if e && l.own != m.own && l.exp.After(now) {
+			return false, nil
+		}
+	}
+
+	{
+		m.sto.lea[key] = lease{exp: now.Add(m.ttl), own: m.own}
+	}
+
+	return true, nil
+}
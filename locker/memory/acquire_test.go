@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func Test_Locker_Memory_Acquire(t *testing.T) {
+	var now time.Time
+	{
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	var sto *Store
+	{
+		sto = &Store{}
+	}
+
+	var one *Memory
+	var two *Memory
+	{
+		one = New(Config{Own: "one", Sto: sto, Ttl: time.Minute})
+		two = New(Config{Own: "two", Sto: sto, Ttl: time.Minute})
+	}
+
+	{
+		one.now = func() time.Time { return now }
+		two.now = func() time.Time { return now }
+	}
+
+	// The first owner acquires the lease, so that the second owner cannot
+	// acquire the same lease anymore. Other leases remain available.
+
+	{
+		tesAcq(t, one, "foo", true)
+		tesAcq(t, two, "foo", false)
+		tesAcq(t, two, "bar", true)
+	}
+
+	// Renewing the lease before it expires keeps the lease with the first owner.
+
+	{
+		now = now.Add(50 * time.Second)
+		tesAcq(t, one, "foo", true)
+	}
+
+	{
+		now = now.Add(50 * time.Second)
+		tesAcq(t, two, "foo", false)
+	}
+
+	// Once the lease expired, the second owner takes over.
+
+	{
+		now = now.Add(20 * time.Second)
+		tesAcq(t, two, "foo", true)
+		tesAcq(t, one, "foo", false)
+	}
+
+	// Releasing a lease held by somebody else is a noop. Releasing a lease held
+	// by the caller makes the lease available right away.
+
+	{
+		err := one.Release(context.Background(), "foo")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesAcq(t, one, "foo", false)
+	}
+
+	{
+		err := two.Release(context.Background(), "foo")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesAcq(t, one, "foo", true)
+	}
+}
+
+func tesAcq(t *testing.T, loc *Memory, key string, exp bool) {
+	t.Helper()
+
+	acq, err := loc.Acquire(context.Background(), key)
+	if err != nil {
+		t.Fatal("expected", nil, "got", err)
+	}
+	if acq != exp {
+		t.Fatal("expected", exp, "got", acq)
+	}
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Own is the optional identifier of the lease owner, e.g. the hostname of the
+	// replica. Defaults to a random identifier.
+	Own string
+
+	// Sto is the optional lease store shared between all lockers competing for
+	// the same leases. Defaults to a private store, which effectively makes every
+	// lease acquirable.
+	Sto *Store
+
+	// Ttl is the optional duration after which an acquired lease expires, unless
+	// it got renewed in the meantime. The lease duration should exceed the cooler
+	// plus the maximum execution time of the worker handlers guarded by this
+	// locker, so that the lease is renewed on every cycle. Leases are renewed
+	// during executions as well, so the lease duration must also exceed the
+	// renewal interval of the worker engine. Defaults to 1 minute.
+	Ttl time.Duration
+}
+
+// Memory is an in-memory implementation of locker.Interface. Memory lockers
+// compete for leases within a single process, which is mostly useful for tests
+// and single-host setups.
+type Memory struct {
+	now func() time.Time
+	own string
+	sto *Store
+	ttl time.Duration
+}
+
+// Store holds the leases shared between all memory lockers using it. The zero
+// value is ready to use.
+type Store struct {
+	lea map[string]lease
+	mut sync.Mutex
+}
+
+type lease struct {
+	exp time.Time
+	own string
+}
+
+func New(c Config) *Memory {
+	if c.Own == "" {
+		c.Own = rand.Text()
+	}
+	if c.Sto == nil {
+		c.Sto = &Store{}
+	}
+	if c.Ttl == 0 {
+		c.Ttl = time.Minute
+	}
+	if c.Ttl < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Ttl must not be negative", c)))
+	}
+
+	return &Memory{
+		now: time.Now,
+		own: c.Own,
+		sto: c.Sto,
+		ttl: c.Ttl,
+	}
+}
@@ -0,0 +1,16 @@
+package memory
+
+import "context"
+
+// Release removes the lease for the given key, if it is held by this owner.
+func (m *Memory) Release(ctx context.Context, key string) error {
+	m.sto.mut.Lock()
+	defer m.sto.mut.Unlock()
+
+	l, e := m.sto.lea[key]
+	if e && l.own == m.own {
+		delete(m.sto.lea, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package observer
+
+import "time"
+
+// Fake is an implementation of Interface without any side effects. Fake is the
+// default observer of all worker engines, and may be embedded by custom
+// observers that only implement some of the lifecycle hooks.
+type Fake struct{}
+
+func (Fake) OnStart(_ string)                              {}
+func (Fake) OnSuccess(_ string, _ time.Duration)           {}
+func (Fake) OnError(_ string, _ time.Duration, _ error)    {}
+func (Fake) OnSkip(_ string)                               {}
+func (Fake) OnCoolerStart(_ string, _ time.Duration)       {}
+func (Fake) OnGraphStart(_ string)                         {}
+func (Fake) OnGraphEnd(_ string, _ time.Duration, _ error) {}
@@ -0,0 +1,43 @@
+package observer
+
+import "time"
+
+// Interface describes the lifecycle hooks that worker engines invoke along the
+// execution of their worker handlers, so that e.g. alerting, audit logs or
+// custom metrics can be implemented without wrapping every worker handler. All
+// hooks are invoked synchronously by the goroutine executing the respective
+// worker handler, which is why implementations must be safe for concurrent use
+// and should return quickly. Worker handlers are identified by handler name.
+type Interface interface {
+	// OnStart is invoked right before the worker handler of the given name gets
+	// executed.
+	OnStart(nam string)
+
+	// OnSuccess is invoked once the worker handler of the given name finished
+	// its execution without error, after the given execution time.
+	OnSuccess(nam string, dur time.Duration)
+
+	// OnError is invoked once the worker handler of the given name failed with
+	// the given error, after the given execution time. Errors filtered by the
+	// metrics registry are reported too.
+	OnError(nam string, dur time.Duration, err error)
+
+	// OnSkip is invoked if the worker handler of the given name is not executed,
+	// e.g. because it declared itself as inactive, because it got paused, or
+	// because any of its dependencies within the graph failed.
+	OnSkip(nam string)
+
+	// OnCoolerStart is invoked once the worker handler of the given name starts
+	// waiting for the given duration until its next execution. For the
+	// *sequence.Worker engine, the given name is the name of the graph.
+	OnCoolerStart(nam string, coo time.Duration)
+
+	// OnGraphStart is invoked right before the *sequence.Worker engine starts
+	// the execution of the graph of the given name.
+	OnGraphStart(nam string)
+
+	// OnGraphEnd is invoked once the *sequence.Worker engine finished the
+	// execution of the graph of the given name, after the given execution time,
+	// with the aggregated error of all failed nodes, if any.
+	OnGraphEnd(nam string, dur time.Duration, err error)
+}
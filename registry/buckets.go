@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"fmt"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// buckets is the default list of histogram bucket boundaries in seconds, used
+// for the execution duration of all worker handlers.
+var buckets = []float64{
+	0.10, //  100 ms
+	0.15, //  150 ms
+	0.20, //  200 ms
+	0.25, //  250 ms
+	0.50, //  500 ms
+
+	1.00, // 1000 ms
+	1.50, // 1500 ms
+	2.00, // 2000 ms
+	2.50, // 2500 ms
+	5.00, // 5000 ms
+}
+
+// bucket returns the histogram bucket boundaries of the given worker handler,
+// if the given worker handler implements handler.Buckets. Otherwise the
+// histogram bucket boundaries of this registry are returned. bucket panics if
+// the bucket boundaries of the given worker handler are invalid.
+func (r *Registry) bucket(han handler.Ensure) []float64 {
+	b, i := han.(handler.Buckets)
+	if !i || b.Buckets() == nil {
+		return r.buc
+	}
+
+	if !increasing(b.Buckets()) {
+		tracer.Panic(tracer.Mask(fmt.Errorf("buckets of handler %s must be sorted in strictly increasing order", handler.Name(han))))
+	}
+
+	return b.Buckets()
+}
+
+// meter returns the meter used to record the execution metrics of the given
+// worker handler. Worker handlers overwriting the histogram bucket boundaries
+// of this registry are recorded using their own meter, because the first
+// instrument of any name defines the buckets of all instruments of the same
+// name within the same meter. Every meter has its own instrumentation scope,
+// e.g. github.com/0xSplits/workit/prices, so that the execution duration of all
+// worker handlers is still exported within the shared histogram family. meter
+// panics if the given worker handler overwrites the histogram bucket
+// boundaries, but no meter provider was configured.
+func (r *Registry) meter(han handler.Ensure) metric.Meter {
+	b, i := han.(handler.Buckets)
+	if !i || b.Buckets() == nil {
+		return r.met
+	}
+
+	if r.pro == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Pro must not be empty for handler %s implementing handler.Buckets", Config{}, handler.Name(han))))
+	}
+
+	return r.pro.Meter(fmt.Sprintf("%s/%s", Scope, handler.Name(han)))
+}
+
+// increasing returns whether the given bucket boundaries are not empty, and
+// sorted in strictly increasing order.
+func increasing(buc []float64) bool {
+	if len(buc) == 0 {
+		return false
+	}
+
+	for i := 1; i < len(buc); i++ {
+		if buc[i] <= buc[i-1] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Registry_increasing(t *testing.T) {
+	testCases := []struct {
+		buc []float64
+		inc bool
+	}{
+		// Case 000
+		{
+			buc: nil,
+			inc: false,
+		},
+		// Case 001
+		{
+			buc: []float64{0.5},
+			inc: true,
+		},
+		// Case 002
+		{
+			buc: []float64{0.1, 1, 10, 60, 600},
+			inc: true,
+		},
+		// Case 003
+		{
+			buc: []float64{0.1, 1, 1, 10},
+			inc: false,
+		},
+		// Case 004
+		{
+			buc: []float64{10, 1},
+			inc: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			inc := increasing(tc.buc)
+			if inc != tc.inc {
+				t.Fatalf("expected %#v got %#v", tc.inc, inc)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// reserved is the list of label keys recorded by the execution metrics of all
+// worker handlers, which must not be overwritten by static handler labels.
+var reserved = []string{
+	"env",
+	"handler",
+	"reason",
+	"success",
+}
+
+// labels returns the static labels of the given worker handler, if the given
+// worker handler implements handler.Labeled. labels panics if any static label
+// is empty, or uses any of the reserved label keys.
+func labels(han handler.Ensure) map[string]string {
+	l, i := han.(handler.Labeled)
+	if !i {
+		return nil
+	}
+
+	var lab map[string]string
+	{
+		lab = l.Labels()
+	}
+
+	for k, v := range lab {
+		if k == "" || v == "" {
+			tracer.Panic(tracer.Mask(fmt.Errorf("labels of handler %s must not be empty", handler.Name(han))))
+		}
+		if slices.Contains(reserved, k) {
+			tracer.Panic(tracer.Mask(fmt.Errorf("labels of handler %s must not contain reserved label %s", handler.Name(han), k)))
+		}
+	}
+
+	return lab
+}
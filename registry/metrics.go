@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/otelgo/registry"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Metric describes a single instrument whitelisted by the metrics registries
+// created via Registry.Metrics.
+type Metric struct {
+	// Buc is the list of explicit bucket boundaries, which is only used for
+	// histograms.
+	Buc []float64
+
+	// Des is the human readable description of the instrument.
+	Des string
+
+	// Lab is the whitelist of label keys and their respective label values that
+	// are allowed to be recorded for the instrument.
+	Lab map[string][]string
+}
+
+// Metrics returns a new metrics registry whitelisting the given counters,
+// gauges and histograms, keyed by their metric names. All instruments are
+// recorded using the meter and the environment of this registry. Metrics allows
+// any wrapper handler to instrument its own behaviour alongside the metrics
+// handlers created via Registry.New.
+func (r *Registry) Metrics(cou map[string]Metric, gau map[string]Metric, his map[string]Metric) registry.Interface {
+	return r.metrics(r.met, cou, gau, his)
+}
+
+// metrics returns a new metrics registry like Metrics does, but records all
+// instruments using the given meter.
+func (r *Registry) metrics(met metric.Meter, cou map[string]Metric, gau map[string]Metric, his map[string]Metric) registry.Interface {
+	c := map[string]recorder.Interface{}
+	for k, v := range cou {
+		c[k] = recorder.NewCounter(recorder.CounterConfig{
+			Des: v.Des,
+			Lab: v.Lab,
+			Met: met,
+			Nam: k,
+		})
+	}
+
+	g := map[string]recorder.Interface{}
+	for k, v := range gau {
+		g[k] = recorder.NewGauge(recorder.GaugeConfig{
+			Des: v.Des,
+			Lab: v.Lab,
+			Met: met,
+			Nam: k,
+		})
+	}
+
+	h := map[string]recorder.Interface{}
+	for k, v := range his {
+		h[k] = recorder.NewHistogram(recorder.HistogramConfig{
+			Buc: v.Buc,
+			Des: v.Des,
+			Lab: v.Lab,
+			Met: met,
+			Nam: k,
+		})
+	}
+
+	return registry.New(registry.Config{
+		Env: r.env,
+		Log: r.log,
+
+		Cou: c,
+		Gau: g,
+		His: h,
+	})
+}
@@ -1,7 +1,8 @@
 package registry
 
 import (
-	"github.com/0xSplits/otelgo/recorder"
+	"slices"
+
 	"github.com/0xSplits/otelgo/registry"
 	"github.com/0xSplits/workit/handler"
 	"github.com/0xSplits/workit/handler/metrics"
@@ -11,8 +12,13 @@ import (
 // New returns a metrics handler by wrapping the given implementation of
 // handler.Ensure within a proxy handler. The returned metrics handler is
 // configured with its own metrics registry according to the underlying
-// configuration.
-func (r *Registry) New(han handler.Ensure) handler.Interface {
+// configuration, where the histogram buckets of the execution duration may be
+// overwritten by the underlying handler via handler.Buckets. The middlewares
+// of this registry and the given middlewares are applied in order between the
+// metrics handler and the proxy handler.
+//
+//	metrics -> registry middlewares -> given middlewares -> proxy -> artefact
+func (r *Registry) New(han handler.Ensure, mid ...handler.Middleware) handler.Interface {
 	var pro handler.Interface
 	{
 		pro = proxy.New(proxy.Config{
@@ -20,71 +26,120 @@ func (r *Registry) New(han handler.Ensure) handler.Interface {
 		})
 	}
 
+	{
+		pro = handler.Chain(pro, slices.Concat(r.mid, mid)...)
+	}
+
 	var nam string
 	{
 		nam = handler.Name(pro.Unwrap())
 	}
 
-	cou := map[string]recorder.Interface{}
+	var lab map[string]string
+	{
+		lab = labels(pro.Unwrap())
+	}
+
+	cou := map[string]Metric{}
 
 	{
-		cou[metrics.MetricTotal] = recorder.NewCounter(recorder.CounterConfig{
+		cou[metrics.MetricTotal] = Metric{
 			Des: "the total amount of worker handler executions",
 			Lab: map[string][]string{
 				"handler": {nam},
 				"success": {"true", "false"},
 			},
-			Met: r.met,
-			Nam: metrics.MetricTotal,
-		})
+		}
+	}
+
+	{
+		cou[metrics.MetricFailure] = Metric{
+			Des: "the total amount of failed worker handler executions by reason",
+			Lab: map[string][]string{
+				"handler": {nam},
+				"reason":  {metrics.ReasonError, metrics.ReasonPanic, metrics.ReasonTimeout},
+			},
+		}
+	}
+
+	{
+		cou[metrics.MetricSkipped] = Metric{
+			Des: "the total amount of worker handler executions skipped for being inactive",
+			Lab: map[string][]string{
+				"handler": {nam},
+			},
+		}
+	}
+
+	gau := map[string]Metric{}
+
+	{
+		gau[metrics.MetricConsecutive] = Metric{
+			Des: "the amount of consecutive failed worker handler executions",
+			Lab: map[string][]string{
+				"handler": {nam},
+			},
+		}
 	}
 
-	gau := map[string]recorder.Interface{}
+	{
+		gau[metrics.MetricInflight] = Metric{
+			Des: "the amount of worker handler executions currently in flight",
+			Lab: map[string][]string{
+				"handler": {nam},
+			},
+		}
+	}
 
-	his := map[string]recorder.Interface{}
+	{
+		gau[metrics.MetricSuccess] = Metric{
+			Des: "the unix timestamp of the last successful worker handler execution",
+			Lab: map[string][]string{
+				"handler": {nam},
+			},
+		}
+	}
+
+	his := map[string]Metric{}
 
 	{
-		his[metrics.MetricDuration] = recorder.NewHistogram(recorder.HistogramConfig{
+		his[metrics.MetricDuration] = Metric{
 			Des: "the time it takes for worker handler executions to complete",
 			Lab: map[string][]string{
 				"handler": {nam},
 				"success": {"true", "false"},
 			},
-			Buc: []float64{
-				0.10, //  100 ms
-				0.15, //  150 ms
-				0.20, //  200 ms
-				0.25, //  250 ms
-				0.50, //  500 ms
-
-				1.00, // 1000 ms
-				1.50, // 1500 ms
-				2.00, // 2000 ms
-				2.50, // 2500 ms
-				5.00, // 5000 ms
-			},
-			Met: r.met,
-			Nam: metrics.MetricDuration,
-		})
+			Buc: r.bucket(pro.Unwrap()),
+		}
 	}
 
+	// Whitelist the static labels of the underlying handler for all of its
+	// execution metrics, so that e.g. the owning team can be queried for.
+
+	for _, x := range []map[string]Metric{cou, gau, his} {
+		for _, y := range x {
+			for k, v := range lab {
+				y.Lab[k] = []string{v}
+			}
+		}
+	}
+
+	// Note that the execution metrics are recorded using a dedicated meter if
+	// the histogram buckets are overwritten by the underlying handler. See
+	// Registry.meter for more information.
+
 	var reg registry.Interface
 	{
-		reg = registry.New(registry.Config{
-			Env: r.env,
-			Log: r.log,
-
-			Cou: cou,
-			Gau: gau,
-			His: his,
-		})
+		reg = r.metrics(r.meter(pro.Unwrap()), cou, gau, his)
 	}
 
 	return metrics.New(metrics.Config{
 		Fil: r.fil,
 		Han: pro,
+		Lab: lab,
 		Log: r.log,
 		Nam: nam,
 		Reg: reg,
+		Tra: r.tra,
 	})
 }
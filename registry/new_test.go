@@ -0,0 +1,356 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/testdata/artefact"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/xh3b4sd/logger"
+	"github.com/xh3b4sd/tracer"
+	exporter "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func Test_Registry_New_middleware(t *testing.T) {
+	testCases := []struct {
+		mid []string
+		ord []string
+		reg []string
+		stk []string
+	}{
+		// Case 000, no middlewares
+		{
+			ord: nil,
+			stk: []string{"metrics", "proxy", "artefact"},
+		},
+		// Case 001, registry middlewares only
+		{
+			ord: []string{"foo", "bar"},
+			reg: []string{"foo", "bar"},
+			stk: []string{"metrics", "registry", "registry", "proxy", "artefact"},
+		},
+		// Case 002, registry middlewares wrap engine middlewares
+		{
+			mid: []string{"bar", "baz"},
+			ord: []string{"foo", "bar", "baz"},
+			reg: []string{"foo"},
+			stk: []string{"metrics", "registry", "registry", "registry", "proxy", "artefact"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var ord []string
+
+			var reg *Registry
+			{
+				reg = tesReg(tesMid(&ord, tc.reg))
+			}
+
+			var han handler.Interface
+			{
+				han = reg.New(&artefact.Handler{}, tesMid(&ord, tc.mid)...)
+			}
+
+			err := han.EnsureContext(context.Background())
+			if err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+
+			if dif := cmp.Diff(tc.ord, ord); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(tc.stk, handler.Names(handler.Stack(han))); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff("artefact", handler.Name(han.Unwrap())); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+type testMiddleware struct {
+	handler.Interface
+	lis *[]string
+	nam string
+}
+
+func (m *testMiddleware) EnsureContext(ctx context.Context) error {
+	*m.lis = append(*m.lis, m.nam)
+	return m.Interface.EnsureContext(ctx)
+}
+
+func (m *testMiddleware) Wrapped() handler.Ensure {
+	return m.Interface
+}
+
+func tesMid(lis *[]string, nam []string) []handler.Middleware {
+	var mid []handler.Middleware
+
+	for _, x := range nam {
+		mid = append(mid, func(han handler.Interface) handler.Interface {
+			return &testMiddleware{Interface: han, lis: lis, nam: x}
+		})
+	}
+
+	return mid
+}
+
+func tesReg(mid []handler.Middleware) *Registry {
+	return New(Config{
+		Env: "testing",
+		Log: logger.Fake(),
+		Met: recorder.NewMeter(recorder.MeterConfig{
+			Env: "testing",
+			Sco: "workit",
+			Ver: "v0.1.0",
+		}),
+		Mid: mid,
+	})
+}
+
+func Test_Registry_New_labels(t *testing.T) {
+	var buf bytes.Buffer
+
+	var reg *Registry
+	{
+		reg = New(Config{
+			Env: "testing",
+			Log: logger.New(logger.Config{
+				Filter: logger.NewLevelFilter("debug"),
+				Writer: &buf,
+			}),
+			Met: recorder.NewMeter(recorder.MeterConfig{
+				Env: "testing",
+				Sco: "workit",
+				Ver: "v0.1.0",
+			}),
+		})
+	}
+
+	var han handler.Interface
+	{
+		han = reg.New(&labelHandler{err: errors.New("test error")})
+	}
+
+	err := han.EnsureContext(context.Background())
+	if err == nil {
+		t.Fatal("expected", "test error", "got", nil)
+	}
+
+	{
+		exp := `"context":[{"key":"domain","value":"pricing"},{"key":"team","value":"core"}]`
+		if !strings.Contains(tracer.Json(err), exp) {
+			t.Fatal("expected", exp, "got", tracer.Json(err))
+		}
+	}
+
+	{
+		exp := `"domain":"pricing", "handler":"prices"`
+		if !strings.Contains(buf.String(), exp) {
+			t.Fatal("expected", exp, "got", buf.String())
+		}
+	}
+
+	{
+		exp := `"success":"false", "team":"core"`
+		if !strings.Contains(buf.String(), exp) {
+			t.Fatal("expected", exp, "got", buf.String())
+		}
+	}
+
+	if strings.Contains(buf.String(), "worker instrumentation failed") {
+		t.Fatal("expected", "no instrumentation failure", "got", buf.String())
+	}
+}
+
+type labelHandler struct {
+	err error
+}
+
+func (h *labelHandler) Active() bool {
+	return true
+}
+
+func (h *labelHandler) Ensure() error {
+	return h.err
+}
+
+func (h *labelHandler) Labels() map[string]string {
+	return map[string]string{"domain": "pricing", "team": "core"}
+}
+
+func (h *labelHandler) Name() string {
+	return "prices"
+}
+
+func Test_Registry_New_metrics(t *testing.T) {
+	var pro *prometheus.Registry
+	{
+		pro = prometheus.NewRegistry()
+	}
+
+	// Worker handlers overwriting the histogram buckets require a meter
+	// provider exporting to the same destination as the meter of the registry.
+
+	var met *sdkmetric.MeterProvider
+	{
+		exp, err := exporter.New(exporter.WithRegisterer(pro), exporter.WithoutCounterSuffixes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		met = sdkmetric.NewMeterProvider(sdkmetric.WithReader(exp))
+	}
+
+	var reg *Registry
+	{
+		reg = New(Config{
+			Buc: []float64{1, 10, 60},
+			Env: "testing",
+			Log: logger.Fake(),
+			Met: met.Meter(Scope),
+			Pro: met,
+		})
+	}
+
+	var act bool
+	var err error
+
+	var foo handler.Interface
+	{
+		foo = reg.New(handler.NewFunc(handler.FuncConfig{
+			Act: func() bool { return act },
+			Fun: func() error { return err },
+			Nam: "foo",
+		}))
+	}
+
+	var bar handler.Interface
+	{
+		bar = reg.New(&bucketHandler{})
+	}
+
+	// Execute foo once successfully, twice failing, and skip it once for being
+	// inactive.
+
+	{
+		act = true
+		err = nil
+		_ = foo.Active()
+		_ = foo.EnsureContext(context.Background())
+	}
+
+	{
+		err = errors.New("test error")
+		_ = foo.EnsureContext(context.Background())
+		_ = foo.EnsureContext(context.Background())
+	}
+
+	{
+		act = false
+		_ = foo.Active()
+	}
+
+	{
+		_ = bar.EnsureContext(context.Background())
+	}
+
+	var res string
+	{
+		res = tesRes(pro)
+	}
+
+	testCases := []string{
+		`worker_handler_consecutive_failures\{env="testing",handler="foo",[^}]*\} 2`,
+		`worker_handler_execution_duration_seconds_bucket\{env="testing",handler="foo",[^}]*,le="60"[^}]*\} 1`,
+		`worker_handler_execution_duration_seconds_bucket\{env="testing",handler="bar",[^}]*,le="0\.001"[^}]*\} 1`,
+		`worker_handler_inflight_executions\{env="testing",handler="foo",[^}]*\} 0`,
+		`worker_handler_last_success_timestamp_seconds\{env="testing",handler="foo",[^}]*\} [0-9.e+]+`,
+		`worker_handler_skipped_total\{env="testing",handler="foo",[^}]*\} 1`,
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			if !regexp.MustCompile(tc).MatchString(res) {
+				t.Fatalf("expected %s to match\n%s", tc, res)
+			}
+		})
+	}
+}
+
+// Test_Registry_New_provider verifies that worker handlers overwriting the
+// histogram buckets are rejected, if no meter provider was configured.
+func Test_Registry_New_provider(t *testing.T) {
+	if os.Getenv("REGISTRY_PROVIDER") == "true" {
+		reg := New(Config{
+			Env: "testing",
+			Log: logger.Fake(),
+			Met: recorder.NewMeter(recorder.MeterConfig{
+				Env: "testing",
+				Sco: "workit",
+				Ver: "v0.1.0",
+			}),
+		})
+
+		reg.New(&bucketHandler{})
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_Registry_New_provider$")
+	cmd.Env = append(os.Environ(), "REGISTRY_PROVIDER=true")
+
+	err := cmd.Run()
+	if err == nil {
+		t.Fatal("expected", "exit status 1", "got", nil)
+	}
+}
+
+type bucketHandler struct{}
+
+func (h *bucketHandler) Active() bool {
+	return true
+}
+
+func (h *bucketHandler) Buckets() []float64 {
+	return []float64{0.001, 0.01}
+}
+
+func (h *bucketHandler) Ensure() error {
+	return nil
+}
+
+func (h *bucketHandler) Name() string {
+	return "bar"
+}
+
+func tesRes(reg *prometheus.Registry) string {
+	var rec *httptest.ResponseRecorder
+	{
+		rec = httptest.NewRecorder()
+	}
+
+	{
+		promhttp.HandlerFor(reg, promhttp.HandlerOpts{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	}
+
+	return rec.Body.String()
+}
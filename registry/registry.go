@@ -3,12 +3,24 @@ package registry
 import (
 	"fmt"
 
+	"github.com/0xSplits/workit/handler"
 	"github.com/xh3b4sd/logger"
 	"github.com/xh3b4sd/tracer"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
+// Scope is the instrumentation scope of all spans recorded by this package.
+const Scope = "github.com/0xSplits/workit"
+
 type Config struct {
+	// Buc is the optional list of histogram bucket boundaries in seconds, used
+	// for the execution duration of all worker handlers, unless the underlying
+	// worker handler implements handler.Buckets. Buc must be sorted in strictly
+	// increasing order. Defaults to buckets from 100 milliseconds to 5 seconds.
+	Buc []float64
+
 	// Env is the environment identifier injected to the internally managed
 	// registry interface to annotate all metrics with the respective label, e.g.
 	// "env=staging".
@@ -27,18 +39,48 @@ type Config struct {
 	// managed registry interface. This meter will record all worker handler
 	// execution metrics.
 	Met metric.Meter
+
+	// Mid is the optional list of middlewares wrapping every worker handler
+	// created by this registry, in the given order. These middlewares are
+	// applied inside of the metrics handler, so that their executions are
+	// instrumented, and outside of any middleware provided by the worker
+	// engines.
+	Mid []handler.Middleware
+
+	// Pro is the optional open telemetry meter provider used to create a
+	// dedicated meter for every worker handler implementing handler.Buckets, so
+	// that its execution duration is recorded using its own bucket boundaries,
+	// while still being exported within the shared histogram family. Pro should
+	// export to the same destination as Met. Pro is required if any worker
+	// handler implements handler.Buckets.
+	Pro metric.MeterProvider
+
+	// Tra is the optional open telemetry tracer provider used to trace every
+	// worker handler execution, as well as every graph execution of the
+	// *sequence.Worker engine. No spans are recorded by default.
+	Tra trace.TracerProvider
 }
 
 // Registry contains all necessary information to wrap user specific worker
 // handlers within new metrics handlers when instantiating a new worker engine.
 type Registry struct {
+	buc []float64
 	env string
 	fil func(error) bool
 	log logger.Interface
 	met metric.Meter
+	mid []handler.Middleware
+	pro metric.MeterProvider
+	tra trace.Tracer
 }
 
 func New(c Config) *Registry {
+	if c.Buc == nil {
+		c.Buc = buckets
+	}
+	if !increasing(c.Buc) {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Buc must be sorted in strictly increasing order", c)))
+	}
 	if c.Env == "" {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Env must not be empty", c)))
 	}
@@ -51,11 +93,18 @@ func New(c Config) *Registry {
 	if c.Met == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Met must not be empty", c)))
 	}
+	if c.Tra == nil {
+		c.Tra = noop.NewTracerProvider()
+	}
 
 	return &Registry{
+		buc: c.Buc,
 		env: c.Env,
 		fil: c.Fil,
 		log: c.Log,
 		met: c.Met,
+		mid: c.Mid,
+		pro: c.Pro,
+		tra: c.Tra.Tracer(Scope),
 	}
 }
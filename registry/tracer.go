@@ -0,0 +1,10 @@
+package registry
+
+import "go.opentelemetry.io/otel/trace"
+
+// Tracer returns the open telemetry tracer of this registry, so that worker
+// engines can trace their own executions alongside the spans of the metrics
+// handlers created via Registry.New.
+func (r *Registry) Tracer() trace.Tracer {
+	return r.tra
+}
@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Registry_New_tracer(t *testing.T) {
+	var exp *tracetest.InMemoryExporter
+	{
+		exp = tracetest.NewInMemoryExporter()
+	}
+
+	var reg *Registry
+	{
+		reg = New(Config{
+			Env: "testing",
+			Log: logger.Fake(),
+			Met: recorder.NewMeter(recorder.MeterConfig{
+				Env: "testing",
+				Sco: "workit",
+				Ver: "v0.1.0",
+			}),
+			Tra: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)),
+		})
+	}
+
+	var spn trace.SpanContext
+
+	var foo handler.Interface
+	{
+		foo = reg.New(handler.NewFunc(handler.FuncConfig{
+			Ctx: func(ctx context.Context) error {
+				spn = trace.SpanContextFromContext(ctx)
+				return errors.New("test error")
+			},
+			Nam: "foo",
+		}))
+	}
+
+	err := foo.EnsureContext(context.Background())
+	if err == nil {
+		t.Fatal("expected", "test error", "got", nil)
+	}
+
+	var sta tracetest.SpanStubs
+	{
+		sta = exp.GetSpans()
+	}
+
+	if dif := cmp.Diff(1, len(sta)); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	if dif := cmp.Diff("foo", sta[0].Name); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	if dif := cmp.Diff(codes.Error, sta[0].Status.Code); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	if dif := cmp.Diff("exception", sta[0].Events[0].Name); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	// The worker handler must receive the span context of its own span, so that
+	// downstream calls join the same trace.
+
+	if dif := cmp.Diff(sta[0].SpanContext.SpanID().String(), spn.SpanID().String()); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
@@ -0,0 +1,40 @@
+package runner
+
+import (
+	"context"
+
+	"github.com/0xSplits/workit/worker/combined"
+	"github.com/0xSplits/workit/worker/parallel"
+	"github.com/0xSplits/workit/worker/sequence"
+)
+
+// daemon executes all configured worker engines until the given context got
+// cancelled.
+func (r *Runner) daemon(ctx context.Context) error {
+	par, seq := r.engines()
+
+	if par != nil && seq != nil {
+		combined.New(combined.Config{Par: par, Seq: seq}).Daemon(ctx)
+	} else if par != nil {
+		par.Daemon(ctx)
+	} else {
+		seq.Daemon(ctx)
+	}
+
+	return nil
+}
+
+// engines creates the configured worker engines, if any.
+func (r *Runner) engines() (*parallel.Worker, *sequence.Worker) {
+	var par *parallel.Worker
+	if r.par != nil {
+		par = parallel.New(*r.par)
+	}
+
+	var seq *sequence.Worker
+	if r.seq != nil {
+		seq = sequence.New(*r.seq)
+	}
+
+	return par, seq
+}
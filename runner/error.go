@@ -0,0 +1,50 @@
+package runner
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+var commandInvalidError = &tracer.Error{
+	Description: "The given command is not supported by the runner.",
+}
+
+var commandMissingError = &tracer.Error{
+	Description: "The runner requires a command to execute.",
+}
+
+var engineMissingError = &tracer.Error{
+	Description: "The given command requires a worker engine that is not configured.",
+}
+
+var handlerMissingError = &tracer.Error{
+	Description: "The run command requires the name of the worker handler to execute.",
+}
+
+var urlMissingError = &tracer.Error{
+	Description: "The status command requires the URL of a remote admin endpoint.",
+}
+
+// InactiveError is returned by the run command if the worker handler to execute
+// declares itself as inactive, and --force is not given.
+var InactiveError = &tracer.Error{
+	Description: "The worker handler is not active and requires --force to be executed.",
+}
+
+// IsInactive returns true if the given error is or wraps InactiveError.
+func IsInactive(err error) bool {
+	return errors.Is(err, InactiveError)
+}
+
+func isCommandInvalid(err error) bool {
+	return errors.Is(err, commandInvalidError)
+}
+
+func isEngineMissing(err error) bool {
+	return errors.Is(err, engineMissingError)
+}
+
+func isUrlMissing(err error) bool {
+	return errors.Is(err, urlMissingError)
+}
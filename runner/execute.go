@@ -0,0 +1,139 @@
+package runner
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+const usage = `usage: <command> [flags]
+
+commands:
+  list                   list all worker handlers of all worker engines
+  run <name> [--force]   execute all worker handlers of the given name once
+  run-graph              execute the graph of the sequence engine once
+  daemon                 execute all worker engines until the context is done
+  status --url <url>     print the runtime snapshot of a running process
+`
+
+// Execute runs the command given by the provided command line arguments,
+// excluding the program name, e.g. os.Args[1:]. Execute blocks until the
+// command finished. The daemon command only finishes once the given context got
+// cancelled, which is why callers may want to provide a context that is
+// cancelled on SIGTERM, e.g. via signal.NotifyContext.
+//
+//	list                   list all worker handlers of all worker engines
+//	run <name> [--force]   execute all worker handlers of the given name once
+//	run-graph              execute the graph of the sequence engine once
+//	daemon                 execute all worker engines until the context is done
+//	status --url <url>     print the runtime snapshot of a running process
+//
+// The run command honors the Active scheduler primitive of the worker
+// handlers, unless --force is given. The status command requires --url to
+// point to a remote admin endpoint serving the runtime snapshot of a running
+// process, because the worker engines of this runner have no runtime state.
+func (r *Runner) Execute(ctx context.Context, arg []string) error {
+	if len(arg) == 0 {
+		fmt.Fprint(r.out, usage)
+		return tracer.Mask(commandMissingError)
+	}
+
+	var cmd string
+	var res []string
+	{
+		cmd = arg[0]
+		res = arg[1:]
+	}
+
+	var fla *flag.FlagSet
+	{
+		fla = flag.NewFlagSet(cmd, flag.ContinueOnError)
+		fla.SetOutput(r.out)
+	}
+
+	switch cmd {
+	case "list":
+		{
+			err := fla.Parse(res)
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		return r.list()
+
+	case "run":
+		var frc bool
+		{
+			fla.BoolVar(&frc, "force", false, "execute the worker handler even if it is not active")
+		}
+
+		// Parse the flags before and after the handler name, because the standard
+		// library stops parsing at the first positional argument.
+
+		{
+			err := fla.Parse(res)
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		if fla.NArg() == 0 {
+			return tracer.Mask(handlerMissingError)
+		}
+
+		var nam string
+		{
+			nam = fla.Arg(0)
+		}
+
+		{
+			err := fla.Parse(fla.Args()[1:])
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		return r.run(ctx, nam, frc)
+
+	case "run-graph":
+		{
+			err := fla.Parse(res)
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		return r.graph(ctx)
+
+	case "daemon":
+		{
+			err := fla.Parse(res)
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		return r.daemon(ctx)
+
+	case "status":
+		var url string
+		{
+			fla.StringVar(&url, "url", "", "the URL of a remote admin endpoint")
+		}
+
+		{
+			err := fla.Parse(res)
+			if err != nil {
+				return tracer.Mask(err)
+			}
+		}
+
+		return r.status(ctx, url)
+	}
+
+	fmt.Fprint(r.out, usage)
+	return tracer.Mask(commandInvalidError, tracer.Context{Key: "command", Value: cmd})
+}
@@ -0,0 +1,310 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/admin"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/handler/dummy"
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/status"
+	"github.com/0xSplits/workit/worker/parallel"
+	"github.com/0xSplits/workit/worker/sequence"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Runner_Execute(t *testing.T) {
+	testCases := []struct {
+		arg []string
+		cal int
+		out string
+		mat func(error) bool
+	}{
+		// Case 000, list all worker handlers
+		{
+			arg: []string{"list"},
+			out: "ENGINE    NAME\nparallel  runner\nparallel  dummy\nsequence  runner\n",
+		},
+		// Case 001, run all worker handlers of the given name
+		{
+			arg: []string{"run", "runner"},
+			cal: 2,
+		},
+		// Case 002, inactive worker handlers require --force
+		{
+			arg: []string{"run", "dummy"},
+			mat: IsInactive,
+		},
+		// Case 003, --force before the handler name
+		{
+			arg: []string{"run", "--force", "dummy"},
+			out: "parallel dummy succeeded",
+		},
+		// Case 004, --force after the handler name
+		{
+			arg: []string{"run", "dummy", "--force"},
+			out: "parallel dummy succeeded",
+		},
+		// Case 005, unknown worker handler
+		{
+			arg: []string{"run", "foo"},
+			mat: status.IsNotFound,
+		},
+		// Case 006, execute the graph
+		{
+			arg: []string{"run-graph"},
+			cal: 1,
+			out: "sequence graph succeeded",
+		},
+		// Case 007, the runtime snapshot requires a remote admin endpoint
+		{
+			arg: []string{"status"},
+			mat: isUrlMissing,
+		},
+		// Case 008, unknown command
+		{
+			arg: []string{"foo"},
+			out: "usage:",
+			mat: isCommandInvalid,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var han *testHandler
+			{
+				han = &testHandler{}
+			}
+
+			var out *bytes.Buffer
+			{
+				out = &bytes.Buffer{}
+			}
+
+			var run *Runner
+			{
+				run = New(Config{
+					Out: out,
+					Par: &parallel.Config{
+						Han: []handler.Cooler{han, dummy.New()},
+						Log: logger.Fake(),
+						Reg: tesReg(),
+					},
+					Seq: &sequence.Config{
+						Han: [][]handler.Ensure{{han}},
+						Log: logger.Fake(),
+						Reg: tesReg(),
+					},
+				})
+			}
+
+			err := run.Execute(context.Background(), tc.arg)
+			if tc.mat == nil && err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+			if tc.mat != nil && !tc.mat(err) {
+				t.Fatal("expected", true, "got", err)
+			}
+
+			if han.cal != tc.cal {
+				t.Fatal("expected", tc.cal, "got", han.cal)
+			}
+
+			if !strings.Contains(out.String(), tc.out) {
+				t.Fatalf("expected %q to contain %q", out.String(), tc.out)
+			}
+		})
+	}
+}
+
+func Test_Runner_Execute_graph(t *testing.T) {
+	var run *Runner
+	{
+		run = New(Config{
+			Out: &bytes.Buffer{},
+			Par: &parallel.Config{
+				Han: []handler.Cooler{dummy.New()},
+				Log: logger.Fake(),
+				Reg: tesReg(),
+			},
+		})
+	}
+
+	err := run.Execute(context.Background(), []string{"run-graph"})
+	if !isEngineMissing(err) {
+		t.Fatal("expected", engineMissingError, "got", err)
+	}
+}
+
+// Test_Runner_Execute_inactive verifies that no worker handler of the given
+// name is executed if any of them declares itself as inactive.
+func Test_Runner_Execute_inactive(t *testing.T) {
+	var act *testHandler
+	var ina *testHandler
+	{
+		act = &testHandler{}
+		ina = &testHandler{ina: true}
+	}
+
+	var run *Runner
+	{
+		run = New(Config{
+			Out: &bytes.Buffer{},
+			Par: &parallel.Config{
+				Han: []handler.Cooler{act},
+				Log: logger.Fake(),
+				Reg: tesReg(),
+			},
+			Seq: &sequence.Config{
+				Han: [][]handler.Ensure{{ina}},
+				Log: logger.Fake(),
+				Reg: tesReg(),
+			},
+		})
+	}
+
+	err := run.Execute(context.Background(), []string{"run", "runner"})
+	if !IsInactive(err) {
+		t.Fatal("expected", InactiveError, "got", err)
+	}
+
+	if act.cal != 0 {
+		t.Fatal("expected", 0, "got", act.cal)
+	}
+}
+
+// Test_Runner_Execute_health verifies that single runs are executed the same
+// way the worker engines execute their worker handlers, so that e.g. their
+// executions are recorded for health reporting.
+func Test_Runner_Execute_health(t *testing.T) {
+	var hea *health.Health
+	{
+		hea = health.New(health.Config{})
+	}
+
+	var run *Runner
+	{
+		run = New(Config{
+			Out: &bytes.Buffer{},
+			Par: &parallel.Config{
+				Han: []handler.Cooler{&testHandler{}},
+				Hea: hea,
+				Log: logger.Fake(),
+				Reg: tesReg(),
+			},
+		})
+	}
+
+	err := run.Execute(context.Background(), []string{"run", "runner"})
+	if err != nil {
+		t.Fatal("expected", nil, "got", err)
+	}
+
+	var rep health.Report
+	{
+		rep = hea.Report()
+	}
+
+	if len(rep.Status) != 1 || rep.Status[0].LastStart.IsZero() || rep.Status[0].LastSuccess.IsZero() {
+		t.Fatal("expected", "recorded execution", "got", rep.Status)
+	}
+}
+
+func Test_Runner_Execute_status(t *testing.T) {
+	var par *parallel.Worker
+	{
+		par = parallel.New(parallel.Config{
+			Han: []handler.Cooler{&testHandler{}},
+			Log: logger.Fake(),
+			Reg: tesReg(),
+		})
+	}
+
+	{
+		err := par.Pause("runner")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	var ser *httptest.Server
+	{
+		ser = httptest.NewServer(admin.New(admin.Config{Log: logger.Fake(), Wor: par}))
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	var out *bytes.Buffer
+	{
+		out = &bytes.Buffer{}
+	}
+
+	var run *Runner
+	{
+		run = New(Config{
+			Out: out,
+			Par: &parallel.Config{
+				Han: []handler.Cooler{dummy.New()},
+				Log: logger.Fake(),
+				Reg: tesReg(),
+			},
+		})
+	}
+
+	{
+		err := run.Execute(context.Background(), []string{"status", "--url", ser.URL})
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	// The remote runtime snapshot reports the paused worker handler of the
+	// remote worker engine, instead of the local worker handlers.
+
+	{
+		exp := "parallel  0      runner  idle   true    true"
+		if !strings.Contains(out.String(), exp) {
+			t.Fatalf("expected %q to contain %q", out.String(), exp)
+		}
+	}
+}
+
+type testHandler struct {
+	cal int
+	ina bool
+}
+
+func (h *testHandler) Active() bool {
+	return !h.ina
+}
+
+func (h *testHandler) Cooler() time.Duration {
+	return time.Minute
+}
+
+func (h *testHandler) Ensure() error {
+	h.cal++
+	return nil
+}
+
+func tesReg() *registry.Registry {
+	return registry.New(registry.Config{
+		Env: "testing",
+		Log: logger.Fake(),
+		Met: recorder.NewMeter(recorder.MeterConfig{
+			Env: "testing",
+			Sco: "workit",
+			Ver: "v0.1.0",
+		}),
+	})
+}
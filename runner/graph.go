@@ -0,0 +1,35 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xSplits/workit/worker/sequence"
+	"github.com/xh3b4sd/tracer"
+)
+
+// graph executes the directed acyclic graph of the *sequence.Worker engine
+// once.
+func (r *Runner) graph(ctx context.Context) error {
+	if r.seq == nil {
+		return tracer.Mask(engineMissingError, tracer.Context{Key: "engine", Value: "sequence"})
+	}
+
+	var sta time.Time
+	{
+		sta = time.Now()
+	}
+
+	err := sequence.New(*r.seq).EnsureContext(ctx)
+	if err != nil {
+		fmt.Fprintf(r.out, "sequence graph failed after %s: %s\n", time.Since(sta), err)
+		return tracer.Mask(err)
+	}
+
+	{
+		fmt.Fprintf(r.out, "sequence graph succeeded after %s\n", time.Since(sta))
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package runner
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// list prints the engine and the name of all worker handlers, in the order of
+// their configuration.
+func (r *Runner) list() error {
+	var tab *tabwriter.Writer
+	{
+		tab = tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+	}
+
+	{
+		fmt.Fprintln(tab, "ENGINE\tNAME")
+	}
+
+	for _, x := range r.han {
+		fmt.Fprintf(tab, "%s\t%s\n", x.eng, x.nam)
+	}
+
+	{
+		err := tab.Flush()
+		if err != nil {
+			return tracer.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/tracer"
+)
+
+// run executes all worker handlers of the given name once, one after another.
+// None of the worker handlers are executed if any of them declares itself as
+// inactive, unless the given force flag is set.
+func (r *Runner) run(ctx context.Context, nam string, frc bool) error {
+	var han []entry
+	for _, x := range r.han {
+		if x.nam == nam {
+			han = append(han, x)
+		}
+	}
+
+	if len(han) == 0 {
+		return tracer.Mask(status.NotFoundError, tracer.Context{Key: "handler", Value: nam})
+	}
+
+	// Verify that all worker handlers of the given name are active before
+	// executing any of them, so that the run command never stops halfway.
+
+	for _, x := range han {
+		if !frc && !x.han.Active() {
+			return tracer.Mask(InactiveError, tracer.Context{Key: "handler", Value: nam})
+		}
+	}
+
+	var err []error
+	for _, x := range han {
+		var sta time.Time
+		{
+			sta = time.Now()
+		}
+
+		e := x.exe.Execute(ctx, x.nam, x.han)
+		if e != nil {
+			fmt.Fprintf(r.out, "%s %s failed after %s: %s\n", x.eng, x.nam, time.Since(sta), e)
+			err = append(err, tracer.Mask(e, tracer.Context{Key: "handler", Value: nam}))
+		} else {
+			fmt.Fprintf(r.out, "%s %s succeeded after %s\n", x.eng, x.nam, time.Since(sta))
+		}
+	}
+
+	if len(err) == 1 {
+		return tracer.Mask(err[0])
+	}
+
+	if len(err) > 1 {
+		return tracer.Mask(errors.Join(err...))
+	}
+
+	return nil
+}
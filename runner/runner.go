@@ -0,0 +1,145 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/0xSplits/workit/executor"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/worker/parallel"
+	"github.com/0xSplits/workit/worker/sequence"
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Cli is the optional HTTP client used to fetch the runtime snapshot of a
+	// remote admin endpoint. Defaults to http.DefaultClient.
+	Cli *http.Client
+
+	// Out is the optional writer that all command output is written to.
+	// Defaults to os.Stdout.
+	Out io.Writer
+
+	// Par is the optional configuration of the *parallel.Worker engine, exactly
+	// as it would be used to run the worker handlers as daemon. Either Par or
+	// Seq must be provided.
+	Par *parallel.Config
+
+	// Seq is the optional configuration of the *sequence.Worker engine, exactly
+	// as it would be used to run the worker handlers as daemon. Either Par or
+	// Seq must be provided.
+	Seq *sequence.Config
+}
+
+// Runner is a command line interface for the worker handlers of the configured
+// worker engines, so that operators can e.g. execute a single worker handler by
+// hand against production configuration. Runner supports the commands "list",
+// "run <name>", "run-graph", "daemon" and "status". See Runner.Execute for
+// more information.
+type Runner struct {
+	cli *http.Client
+	han []entry
+	out io.Writer
+	par *parallel.Config
+	seq *sequence.Config
+}
+
+// entry is a single worker handler of any of the configured worker engines,
+// wrapped the same way the respective worker engine wraps it, together with the
+// executor configured the same way the respective worker engine configures it.
+type entry struct {
+	eng string
+	exe *executor.Executor
+	han handler.Interface
+	nam string
+}
+
+func New(c Config) *Runner {
+	if c.Cli == nil {
+		c.Cli = http.DefaultClient
+	}
+	if c.Out == nil {
+		c.Out = os.Stdout
+	}
+	if c.Par == nil && c.Seq == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Par or %T.Seq must not be empty", c, c)))
+	}
+	if c.Par != nil && c.Par.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Par.Reg must not be empty", c)))
+	}
+	if c.Seq != nil && c.Seq.Reg == nil {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Seq.Reg must not be empty", c)))
+	}
+
+	// Wrap all worker handlers of all configured worker engines, so that they
+	// can be listed and executed individually, while being instrumented the same
+	// way as within their worker engines.
+
+	var han []entry
+
+	if c.Par != nil {
+		var exe *executor.Executor
+		{
+			exe = execute(c.Par.Hea, c.Par.Pan, c.Par.Reg, c.Par.Tim)
+		}
+
+		for _, x := range c.Par.Han {
+			han = append(han, wrap("parallel", exe, c.Par.Reg.New(x, c.Par.Mid...)))
+		}
+	}
+
+	if c.Seq != nil {
+		var exe *executor.Executor
+		{
+			exe = execute(c.Seq.Hea, c.Seq.Pan, c.Seq.Reg, c.Seq.Tim)
+		}
+
+		for _, x := range c.Seq.Han {
+			for _, y := range x {
+				han = append(han, wrap("sequence", exe, c.Seq.Reg.New(y, c.Seq.Mid...)))
+			}
+		}
+
+		for _, x := range c.Seq.Nod {
+			han = append(han, wrap("sequence", exe, c.Seq.Reg.New(x.Han, c.Seq.Mid...)))
+		}
+	}
+
+	return &Runner{
+		cli: c.Cli,
+		han: han,
+		out: c.Out,
+		par: c.Par,
+		seq: c.Seq,
+	}
+}
+
+// execute returns the executor of a single worker engine, configured the same
+// way the worker engine configures its own executor, so that single executions
+// apply timeouts, panic recovery and health reporting the same way.
+func execute(hea *health.Health, pan bool, reg *registry.Registry, tim time.Duration) *executor.Executor {
+	if hea == nil {
+		hea = health.New(health.Config{})
+	}
+
+	return executor.New(executor.Config{
+		Hea: hea,
+		Pan: pan,
+		Reg: reg,
+		Tim: tim,
+	})
+}
+
+func wrap(eng string, exe *executor.Executor, han handler.Interface) entry {
+	return entry{
+		eng: eng,
+		exe: exe,
+		han: han,
+		nam: handler.Name(han.Unwrap()),
+	}
+}
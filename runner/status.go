@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/tabwriter"
+
+	"github.com/0xSplits/workit/admin"
+	"github.com/xh3b4sd/tracer"
+)
+
+// status prints the runtime snapshot of all worker handlers as served by the
+// remote admin endpoint at the given URL. The given URL is required, because
+// the worker engines configured for this runner are never started by the
+// status command, which means they have no runtime state to report.
+func (r *Runner) status(ctx context.Context, url string) error {
+	if url == "" {
+		return tracer.Mask(urlMissingError)
+	}
+
+	res, err := r.remote(ctx, url)
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	var tab *tabwriter.Writer
+	{
+		tab = tabwriter.NewWriter(r.out, 0, 0, 2, ' ', 0)
+	}
+
+	{
+		fmt.Fprintln(tab, "ENGINE\tSTAGE\tNAME\tSTATE\tACTIVE\tPAUSED\tERROR")
+	}
+
+	for _, x := range res {
+		fmt.Fprintf(tab, "%s\t%d\t%s\t%s\t%t\t%t\t%s\n", x.Engine, x.Stage, x.Name, x.State, x.Active, x.Paused, x.Error)
+	}
+
+	{
+		err := tab.Flush()
+		if err != nil {
+			return tracer.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+// remote returns the runtime snapshot served by the admin endpoint at the given
+// URL.
+func (r *Runner) remote(ctx context.Context, url string) ([]admin.Resource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, tracer.Mask(err)
+	}
+
+	rsp, err := r.cli.Do(req)
+	if err != nil {
+		return nil, tracer.Mask(err)
+	}
+
+	defer rsp.Body.Close() // nolint:errcheck
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, tracer.Mask(fmt.Errorf("admin endpoint responded with %s", rsp.Status))
+	}
+
+	var res []admin.Resource
+	{
+		err := json.NewDecoder(rsp.Body).Decode(&res)
+		if err != nil {
+			return nil, tracer.Mask(err)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,288 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// cron is the parsed representation of a cron expression. Every field is a
+// bit set, where bit n is set if the value n matches the respective field.
+type cron struct {
+	sec uint64
+	min uint64
+	hou uint64
+	dom uint64
+	mon uint64
+	dow uint64
+
+	// any is true if either the day of month or the day of week field is
+	// unrestricted, in which case both fields must match. Otherwise, if both
+	// fields are restricted, either of them must match.
+	any bool
+}
+
+// bounds describes the allowed value range of a single cron field, as well as
+// the optional names that may be used instead of numbers.
+type bounds struct {
+	min int
+	max int
+	nam map[string]int
+}
+
+var (
+	secBnd = bounds{min: 0, max: 59}
+	minBnd = bounds{min: 0, max: 59}
+	houBnd = bounds{min: 0, max: 23}
+	domBnd = bounds{min: 1, max: 31}
+	monBnd = bounds{min: 1, max: 12, nam: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowBnd = bounds{min: 0, max: 7, nam: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// parse converts the given cron expression into its bit set representation.
+// The standard 5 field format is extended with a leading seconds field of 0.
+func parse(exp string) (*cron, error) {
+	var fie []string
+	{
+		fie = strings.Fields(exp)
+	}
+
+	if len(fie) == 1 {
+		des, exi := descriptors[strings.ToLower(fie[0])]
+		if !exi {
+			return nil, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "expression", Value: exp})
+		}
+
+		fie = strings.Fields(des)
+	}
+
+	if len(fie) == 5 {
+		fie = append([]string{"0"}, fie...)
+	}
+
+	if len(fie) != 6 {
+		return nil, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "expression", Value: exp})
+	}
+
+	var err error
+	var c cron
+
+	for i, x := range []struct {
+		bit *uint64
+		bnd bounds
+	}{
+		{&c.sec, secBnd},
+		{&c.min, minBnd},
+		{&c.hou, houBnd},
+		{&c.dom, domBnd},
+		{&c.mon, monBnd},
+		{&c.dow, dowBnd},
+	} {
+		*x.bit, err = field(fie[i], x.bnd)
+		if err != nil {
+			return nil, tracer.Mask(err, tracer.Context{Key: "expression", Value: exp})
+		}
+	}
+
+	// Day of week 7 is an alias for Sunday.
+
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1<<0
+	}
+
+	{
+		c.any = unrestricted(fie[3]) || unrestricted(fie[5])
+	}
+
+	return &c, nil
+}
+
+// field parses a single cron field, which is a comma separated list of ranges
+// with optional steps, e.g. "*/15", "1-5", "MON-FRI" or "0,30".
+func field(fie string, bnd bounds) (uint64, error) {
+	var bit uint64
+
+	for _, x := range strings.Split(fie, ",") {
+		var ran string
+		var stp int
+		{
+			ran = x
+			stp = 1
+		}
+
+		if i := strings.Index(x, "/"); i != -1 {
+			var err error
+
+			ran = x[:i]
+			stp, err = strconv.Atoi(x[i+1:])
+			if err != nil || stp <= 0 {
+				return 0, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "field", Value: fie})
+			}
+		}
+
+		var low int
+		var hig int
+		if ran == "*" || ran == "?" {
+			low = bnd.min
+			hig = bnd.max
+		} else if i := strings.Index(ran, "-"); i != -1 {
+			var err error
+
+			low, err = value(ran[:i], bnd)
+			if err != nil {
+				return 0, tracer.Mask(err, tracer.Context{Key: "field", Value: fie})
+			}
+
+			hig, err = value(ran[i+1:], bnd)
+			if err != nil {
+				return 0, tracer.Mask(err, tracer.Context{Key: "field", Value: fie})
+			}
+		} else {
+			var err error
+
+			low, err = value(ran, bnd)
+			if err != nil {
+				return 0, tracer.Mask(err, tracer.Context{Key: "field", Value: fie})
+			}
+
+			// A single value with a step, e.g. "5/15", ranges until the upper
+			// bound of the field.
+
+			if strings.Contains(x, "/") {
+				hig = bnd.max
+			} else {
+				hig = low
+			}
+		}
+
+		if low > hig {
+			return 0, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "field", Value: fie})
+		}
+
+		for i := low; i <= hig; i += stp {
+			bit |= 1 << uint(i)
+		}
+	}
+
+	return bit, nil
+}
+
+// value parses a single numeric or named value within the given bounds.
+func value(val string, bnd bounds) (int, error) {
+	if num, exi := bnd.nam[strings.ToLower(val)]; exi {
+		return num, nil
+	}
+
+	num, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "value", Value: val})
+	}
+
+	if num < bnd.min || num > bnd.max {
+		return 0, tracer.Mask(cronExpressionInvalidError, tracer.Context{Key: "value", Value: fmt.Sprintf("%d not within [%d, %d]", num, bnd.min, bnd.max)})
+	}
+
+	return num, nil
+}
+
+func unrestricted(fie string) bool {
+	return fie == "*" || fie == "?"
+}
+
+// next returns the earliest time strictly after the given time matching the
+// cron expression, or the zero time if no such time exists within the next
+// five years, e.g. for expressions like "0 0 30 2 *". Every wall clock time
+// matches at most once, even if the clock was set back during a daylight saving
+// time transition. Note that the given time must already be converted into the
+// desired time zone.
+func (c *cron) next(t time.Time) time.Time {
+	var loc *time.Location
+	var end time.Time
+	{
+		loc = t.Location()
+		end = t.AddDate(5, 0, 0)
+	}
+
+	{
+		t = t.Truncate(time.Second).Add(time.Second)
+	}
+
+	for t.Before(end) {
+		if c.mon&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !c.day(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		// Note that we advance hours, minutes and seconds using absolute
+		// durations, so that we never get stuck in daylight saving time
+		// transitions.
+
+		if c.hou&(1<<uint(t.Hour())) == 0 {
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+
+		if c.min&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute - time.Duration(t.Second())*time.Second)
+			continue
+		}
+
+		if c.sec&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+
+		// Skip the wall clock times repeated after the clock was set back during
+		// a daylight saving time transition, because all of them matched already
+		// before the transition.
+
+		if end := after(t); !end.IsZero() {
+			t = end
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// day returns whether the given day matches the day of month and the day of
+// week fields of the cron expression.
+func (c *cron) day(t time.Time) bool {
+	var dom bool
+	var dow bool
+	{
+		dom = c.dom&(1<<uint(t.Day())) != 0
+		dow = c.dow&(1<<uint(t.Weekday())) != 0
+	}
+
+	if c.any {
+		return dom && dow
+	}
+
+	return dom || dow
+}
@@ -0,0 +1,73 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Schedule_Cron_parse(t *testing.T) {
+	testCases := []struct {
+		exp string
+		err bool
+	}{
+		// Case 000
+		{
+			exp: "* * * * *",
+			err: false,
+		},
+		// Case 001
+		{
+			exp: "0 */15 9-17 ? JAN-MAR,DEC mon-fri",
+			err: false,
+		},
+		// Case 002
+		{
+			exp: "5/10 * * * *",
+			err: false,
+		},
+		// Case 003, too few fields
+		{
+			exp: "* * * *",
+			err: true,
+		},
+		// Case 004, too many fields
+		{
+			exp: "* * * * * * *",
+			err: true,
+		},
+		// Case 005, value out of bounds
+		{
+			exp: "60 * * * *",
+			err: true,
+		},
+		// Case 006, invalid step
+		{
+			exp: "*/0 * * * *",
+			err: true,
+		},
+		// Case 007, inverted range
+		{
+			exp: "* 5-1 * * *",
+			err: true,
+		},
+		// Case 008, unknown name
+		{
+			exp: "* * * foo *",
+			err: true,
+		},
+		// Case 009, unknown descriptor
+		{
+			exp: "@foo",
+			err: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			_, err := parse(tc.exp)
+			if tc.err != isCronExpressionInvalid(err) {
+				t.Fatal("expected", tc.err, "got", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package schedule
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+var cronExpressionInvalidError = &tracer.Error{
+	Description: "The cron expression of the schedule is not valid.",
+}
+
+func isCronExpressionInvalid(err error) bool {
+	return errors.Is(err, cronExpressionInvalidError)
+}
@@ -0,0 +1,17 @@
+package schedule
+
+import "time"
+
+// Interface describes a strict schedule for worker handlers. Other than the
+// cooler of worker handlers, a strict schedule defines activation times that
+// are independent of the execution time of the worker handlers.
+type Interface interface {
+	// Missed returns the policy describing how worker engines should deal with
+	// activation times that passed while the worker handler was still executing.
+	// Missed returns one of MissedAll, MissedOnce or MissedSkip.
+	Missed() string
+
+	// Next returns the next activation time strictly after the given time. The
+	// zero time is returned if there is no further activation time.
+	Next(time.Time) time.Time
+}
@@ -0,0 +1,101 @@
+package schedule
+
+import "time"
+
+// Next returns the next activation time strictly after the given time, either
+// according to the configured interval, or according to the configured cron
+// expression.
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.dur != 0 {
+		return s.nxtDur(t.In(s.loc))
+	}
+
+	return s.cro.next(t.In(s.loc))
+}
+
+func (s *Schedule) nxtDur(t time.Time) time.Time {
+	// Intervals not dividing a day evenly are aligned to the Unix epoch, which
+	// is independent of any daylight saving time transition.
+
+	if (24*time.Hour)%s.dur != 0 {
+		var anc time.Time
+		{
+			anc = time.Unix(0, 0).In(s.loc)
+		}
+
+		var num time.Duration
+		{
+			num = t.Sub(anc) / s.dur
+		}
+
+		return anc.Add((num + 1) * s.dur)
+	}
+
+	// Intervals dividing a day evenly are aligned to midnight of the given day
+	// in the configured time zone. Activation times are computed in wall clock
+	// fields, so that e.g. an interval of 6 hours activates at 06:00 on daylight
+	// saving time transition days too. Activation times that do not exist on the
+	// wall clock are moved forward, and activation times within repeated wall
+	// clock times are skipped, so that no activation time fires twice.
+
+	var y, d int
+	var m time.Month
+	{
+		y, m, d = t.Date()
+	}
+
+	var wal time.Duration
+	{
+		wal = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	}
+
+	for slo := (wal/s.dur + 1) * s.dur; ; slo += s.dur {
+		nxt := time.Date(y, m, d, 0, 0, 0, int(slo), s.loc)
+		if nxt.After(t) && !repeated(nxt) {
+			return nxt
+		}
+	}
+}
+
+// repeated returns whether the wall clock time of the given time occurred
+// already before, because the clock was set back during a daylight saving time
+// transition, e.g. 01:30 for the second time at the end of summer time.
+func repeated(t time.Time) bool {
+	return !after(t).IsZero()
+}
+
+// after returns the end of the repeated wall clock times that the given time
+// belongs to, or the zero time if the wall clock time of the given time did not
+// occur before.
+func after(t time.Time) time.Time {
+	var sta time.Time
+	{
+		sta, _ = t.ZoneBounds()
+	}
+
+	if sta.IsZero() {
+		return time.Time{}
+	}
+
+	var cur int
+	var prv int
+	{
+		_, cur = t.Zone()
+		_, prv = sta.Add(-time.Second).Zone()
+	}
+
+	if prv <= cur {
+		return time.Time{}
+	}
+
+	var end time.Time
+	{
+		end = sta.Add(time.Duration(prv-cur) * time.Second)
+	}
+
+	if !t.Before(end) {
+		return time.Time{}
+	}
+
+	return end
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Schedule_Next(t *testing.T) {
+	var ber *time.Location
+	{
+		ber = musLoc("Europe/Berlin")
+	}
+
+	var kol *time.Location
+	{
+		kol = musLoc("Asia/Kolkata")
+	}
+
+	testCases := []struct {
+		con Config
+		now time.Time
+		nxt time.Time
+	}{
+		// Case 000, interval aligned to the wall clock
+		{
+			con: Config{Dur: 15 * time.Minute},
+			now: time.Date(2025, 3, 4, 10, 7, 12, 0, time.UTC),
+			nxt: time.Date(2025, 3, 4, 10, 15, 0, 0, time.UTC),
+		},
+		// Case 001, interval exactly on an activation time
+		{
+			con: Config{Dur: 15 * time.Minute},
+			now: time.Date(2025, 3, 4, 10, 15, 0, 0, time.UTC),
+			nxt: time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC),
+		},
+		// Case 002, interval aligned to midnight in a half hour time zone
+		{
+			con: Config{Dur: time.Hour, Loc: kol},
+			now: time.Date(2025, 3, 4, 10, 7, 0, 0, kol),
+			nxt: time.Date(2025, 3, 4, 11, 0, 0, 0, kol),
+		},
+		// Case 003, interval not dividing a day aligned to the Unix epoch
+		{
+			con: Config{Dur: 7 * time.Hour},
+			now: time.Unix(0, 0).Add(8 * time.Hour),
+			nxt: time.Unix(0, 0).Add(14 * time.Hour),
+		},
+		// Case 004, 5 field cron expression
+		{
+			con: Config{Cro: "*/5 * * * *"},
+			now: time.Date(2025, 3, 4, 10, 7, 12, 0, time.UTC),
+			nxt: time.Date(2025, 3, 4, 10, 10, 0, 0, time.UTC),
+		},
+		// Case 005, 6 field cron expression
+		{
+			con: Config{Cro: "30 */5 * * * *"},
+			now: time.Date(2025, 3, 4, 10, 10, 30, 0, time.UTC),
+			nxt: time.Date(2025, 3, 4, 10, 15, 30, 0, time.UTC),
+		},
+		// Case 006, named weekdays rolling over into the next week
+		{
+			con: Config{Cro: "0 9 * * MON-FRI"},
+			now: time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC), // Friday
+			nxt: time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), // Monday
+		},
+		// Case 007, named months rolling over into the next year
+		{
+			con: Config{Cro: "0 0 1 jan,jul *"},
+			now: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			nxt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		// Case 008, restricted day of month and day of week match either
+		{
+			con: Config{Cro: "0 0 13 * 5"},
+			now: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+			nxt: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), // Friday before the 13th
+		},
+		// Case 009, day of week 7 is Sunday
+		{
+			con: Config{Cro: "0 0 * * 7"},
+			now: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+			nxt: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+		},
+		// Case 010, descriptor
+		{
+			con: Config{Cro: "@daily"},
+			now: time.Date(2025, 3, 4, 10, 7, 12, 0, time.UTC),
+			nxt: time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+		},
+		// Case 011, cron expression within a time zone
+		{
+			con: Config{Cro: "0 9 * * *", Loc: ber},
+			now: time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+			nxt: time.Date(2025, 3, 5, 9, 0, 0, 0, ber),
+		},
+		// Case 012, cron expression skipping a daylight saving time gap
+		{
+			con: Config{Cro: "30 * * * *", Loc: ber},
+			now: time.Date(2025, 3, 30, 1, 45, 0, 0, ber),
+			nxt: time.Date(2025, 3, 30, 3, 30, 0, 0, ber),
+		},
+		// Case 013, impossible date
+		{
+			con: Config{Cro: "0 0 30 2 *"},
+			now: time.Date(2025, 3, 4, 10, 7, 12, 0, time.UTC),
+			nxt: time.Time{},
+		},
+		// Case 014, cron expression within the repeated hour of a daylight saving
+		// time transition fires only once, at 02:30 CEST
+		{
+			con: Config{Cro: "30 2 * * *", Loc: ber},
+			now: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC),
+			nxt: time.Date(2025, 10, 27, 2, 30, 0, 0, ber),
+		},
+		// Case 015, cron expression skipping the repeated hour of a daylight
+		// saving time transition, starting at 02:00 CEST
+		{
+			con: Config{Cro: "0 * * * *", Loc: ber},
+			now: time.Date(2025, 10, 26, 0, 0, 0, 0, time.UTC),
+			nxt: time.Date(2025, 10, 26, 3, 0, 0, 0, ber),
+		},
+		// Case 016, interval aligned to the wall clock on a daylight saving time
+		// transition day without summer time
+		{
+			con: Config{Dur: 6 * time.Hour, Loc: ber},
+			now: time.Date(2025, 3, 30, 0, 0, 0, 0, ber),
+			nxt: time.Date(2025, 3, 30, 6, 0, 0, 0, ber),
+		},
+		// Case 017, interval aligned to the wall clock on a daylight saving time
+		// transition day with summer time
+		{
+			con: Config{Dur: 6 * time.Hour, Loc: ber},
+			now: time.Date(2025, 10, 26, 6, 0, 0, 0, ber),
+			nxt: time.Date(2025, 10, 26, 12, 0, 0, 0, ber),
+		},
+		// Case 018, interval skipping the repeated hour of a daylight saving time
+		// transition, starting at 02:30 CEST
+		{
+			con: Config{Dur: 30 * time.Minute, Loc: ber},
+			now: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC),
+			nxt: time.Date(2025, 10, 26, 3, 0, 0, 0, ber),
+		},
+		// Case 019, interval moving activation times within a daylight saving time
+		// gap forward to 03:00 CEST
+		{
+			con: Config{Dur: time.Hour, Loc: ber},
+			now: time.Date(2025, 3, 30, 1, 30, 0, 0, ber),
+			nxt: time.Date(2025, 3, 30, 3, 0, 0, 0, ber),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sch *Schedule
+			{
+				sch = New(tc.con)
+			}
+
+			var nxt time.Time
+			{
+				nxt = sch.Next(tc.now)
+			}
+
+			if !nxt.Equal(tc.nxt) {
+				t.Fatalf("expected %s got %s", tc.nxt, nxt)
+			}
+
+			if dif := cmp.Diff(MissedSkip, sch.Missed()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+func musLoc(nam string) *time.Location {
+	loc, err := time.LoadLocation(nam)
+	if err != nil {
+		panic(err)
+	}
+
+	return loc
+}
@@ -0,0 +1,106 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	// MissedAll executes the worker handler once for every missed activation
+	// time, one after another, until the schedule caught up.
+	MissedAll = "all"
+	// MissedOnce executes the worker handler once immediately, regardless of
+	// the amount of missed activation times, before returning to the schedule.
+	MissedOnce = "once"
+	// MissedSkip ignores all missed activation times and waits for the next
+	// activation time in the future.
+	MissedSkip = "skip"
+)
+
+type Config struct {
+	// Cro is the cron expression describing the activation times of this
+	// schedule. Both the standard 5 field format and the 6 field format with
+	// leading seconds are supported, as well as descriptors like @hourly. Either
+	// Cro or Dur must be provided.
+	//
+	//     ┌───────────── second (0-59), optional
+	//     │ ┌─────────── minute (0-59)
+	//     │ │ ┌───────── hour (0-23)
+	//     │ │ │ ┌─────── day of month (1-31)
+	//     │ │ │ │ ┌───── month (1-12 or JAN-DEC)
+	//     │ │ │ │ │ ┌─── day of week (0-7 or SUN-SAT)
+	//     │ │ │ │ │ │
+	//     * * * * * *
+	//
+	Cro string
+
+	// Dur is the fixed interval describing the activation times of this
+	// schedule. Intervals are aligned to the wall clock, so that e.g. an interval
+	// of 15 minutes activates at minute 0, 15, 30 and 45 of every hour. Intervals
+	// dividing 24 hours evenly are aligned to midnight in Loc, and are not
+	// shifted by daylight saving time transitions. All other intervals are
+	// aligned to the Unix epoch. Either Cro or Dur must be provided.
+	Dur time.Duration
+
+	// Loc is the optional time zone in which activation times are computed.
+	// Defaults to UTC.
+	Loc *time.Location
+
+	// Mis is the optional policy for missed activation times, one of MissedAll,
+	// MissedOnce or MissedSkip. Defaults to MissedSkip.
+	Mis string
+}
+
+// Schedule is the default implementation of Interface, supporting fixed
+// intervals as well as cron expressions.
+type Schedule struct {
+	cro *cron
+	dur time.Duration
+	loc *time.Location
+	mis string
+}
+
+func New(c Config) *Schedule {
+	if c.Cro == "" && c.Dur == 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Cro or %T.Dur must not be empty", c, c)))
+	}
+	if c.Cro != "" && c.Dur != 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Cro and %T.Dur must not be used together", c, c)))
+	}
+	if c.Dur < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Dur must not be negative", c)))
+	}
+	if c.Loc == nil {
+		c.Loc = time.UTC
+	}
+	if c.Mis == "" {
+		c.Mis = MissedSkip
+	}
+	if c.Mis != MissedAll && c.Mis != MissedOnce && c.Mis != MissedSkip {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Mis must be one of %q, %q or %q", c, MissedAll, MissedOnce, MissedSkip)))
+	}
+
+	var cro *cron
+	if c.Cro != "" {
+		var err error
+
+		cro, err = parse(c.Cro)
+		if err != nil {
+			tracer.Panic(tracer.Mask(err))
+		}
+	}
+
+	return &Schedule{
+		cro: cro,
+		dur: c.Dur,
+		loc: c.Loc,
+		mis: c.Mis,
+	}
+}
+
+// Missed returns the configured policy for missed activation times.
+func (s *Schedule) Missed() string {
+	return s.mis
+}
@@ -0,0 +1,24 @@
+package semaphore
+
+import (
+	"context"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Acquire blocks until a slot of the given resource group is available, or
+// until the given context got cancelled. Every successful call to Acquire must
+// be followed by a call to Release once the guarded execution finished.
+func (s *Semaphore) Acquire(ctx context.Context, grp string) error {
+	sem, exi := s.sem[grp]
+	if !exi {
+		return tracer.Mask(groupNotFoundError, tracer.Context{Key: "group", Value: grp})
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return tracer.Mask(ctx.Err())
+	}
+}
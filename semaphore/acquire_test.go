@@ -0,0 +1,71 @@
+package semaphore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func Test_Semaphore_Acquire(t *testing.T) {
+	var sem *Semaphore
+	{
+		sem = New(Config{
+			Lim: map[string]int{
+				"rpc": 2,
+				"sql": 1,
+			},
+		})
+	}
+
+	// Acquire all slots of the rpc group, while the sql group remains
+	// available.
+
+	{
+		tesAcq(t, sem, "rpc", nil)
+		tesAcq(t, sem, "rpc", nil)
+		tesAcq(t, sem, "rpc", context.DeadlineExceeded)
+		tesAcq(t, sem, "sql", nil)
+	}
+
+	// Releasing a single slot makes the rpc group available again.
+
+	{
+		sem.Release("rpc")
+	}
+
+	{
+		tesAcq(t, sem, "rpc", nil)
+		tesAcq(t, sem, "rpc", context.DeadlineExceeded)
+	}
+
+	// Unknown groups cannot be acquired.
+
+	{
+		err := sem.Acquire(context.Background(), "foo")
+		if !isGroupNotFound(err) {
+			t.Fatal("expected", groupNotFoundError, "got", err)
+		}
+	}
+
+	{
+		if !sem.Exists("rpc") {
+			t.Fatal("expected", true, "got", false)
+		}
+		if sem.Exists("foo") {
+			t.Fatal("expected", false, "got", true)
+		}
+	}
+}
+
+func tesAcq(t *testing.T, sem *Semaphore, grp string, exp error) {
+	t.Helper()
+
+	ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer can()
+
+	err := sem.Acquire(ctx, grp)
+	if !errors.Is(err, exp) {
+		t.Fatal("expected", exp, "got", err)
+	}
+}
@@ -0,0 +1,15 @@
+package semaphore
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+var groupNotFoundError = &tracer.Error{
+	Description: "The given resource group is not configured for this semaphore.",
+}
+
+func isGroupNotFound(err error) bool {
+	return errors.Is(err, groupNotFoundError)
+}
@@ -0,0 +1,9 @@
+package semaphore
+
+// Exists returns whether the given resource group is configured for this
+// semaphore, so that worker engines can verify early on that the resource
+// groups of their worker handlers exist.
+func (s *Semaphore) Exists(grp string) bool {
+	_, exi := s.sem[grp]
+	return exi
+}
@@ -0,0 +1,14 @@
+package semaphore
+
+// Release frees the slot of the given resource group that got acquired before.
+func (s *Semaphore) Release(grp string) {
+	sem, exi := s.sem[grp]
+	if !exi {
+		return
+	}
+
+	select {
+	case <-sem:
+	default:
+	}
+}
@@ -0,0 +1,50 @@
+package semaphore
+
+import (
+	"fmt"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+type Config struct {
+	// Lim is the maximum number of concurrent executions per resource group,
+	// keyed by group name, e.g. at most 3 worker handlers calling the same RPC
+	// provider at once.
+	Lim map[string]int
+}
+
+// Semaphore bounds the number of concurrent executions of all worker handlers
+// sharing the same resource group. The same semaphore may be shared between
+// multiple worker engines, so that their worker handlers compete for the same
+// resource groups.
+type Semaphore struct {
+	sem map[string]chan struct{}
+}
+
+func New(c Config) *Semaphore {
+	if len(c.Lim) == 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Lim must not be empty", c)))
+	}
+
+	var sem map[string]chan struct{}
+	{
+		sem = map[string]chan struct{}{}
+	}
+
+	for k, v := range c.Lim {
+		if k == "" {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Lim must not contain empty group names", c)))
+		}
+		if v <= 0 {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Lim[%s] must be positive", c, k)))
+		}
+
+		{
+			sem[k] = make(chan struct{}, v)
+		}
+	}
+
+	return &Semaphore{
+		sem: sem,
+	}
+}
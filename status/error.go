@@ -0,0 +1,31 @@
+package status
+
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// NotFoundError is returned by worker engines if an operator addresses a
+// worker handler by a name that the worker engine does not manage.
+var NotFoundError = &tracer.Error{
+	Description: "The worker engine does not manage any worker handler of the given name.",
+}
+
+// IsNotFound returns true if the given error is or wraps NotFoundError.
+func IsNotFound(err error) bool {
+	return errors.Is(err, NotFoundError)
+}
+
+// DaemonDisabledError is returned by worker engines if an operator triggers a
+// worker handler, while the worker engine does not execute its worker handlers
+// continuously, e.g. because no cooler duration was configured.
+var DaemonDisabledError = &tracer.Error{
+	Description: "The worker engine cannot trigger worker handlers, because its daemon is disabled.",
+}
+
+// IsDaemonDisabled returns true if the given error is or wraps
+// DaemonDisabledError.
+func IsDaemonDisabled(err error) bool {
+	return errors.Is(err, DaemonDisabledError)
+}
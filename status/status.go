@@ -0,0 +1,60 @@
+package status
+
+import "time"
+
+const (
+	// StateCooling is the state of worker handlers waiting for their next
+	// execution, after completing their last cycle.
+	StateCooling = "cooling"
+
+	// StateDisabled is the state of worker handlers that skipped their last
+	// cycle, either because they declared themselves as inactive, or because
+	// they got paused.
+	StateDisabled = "disabled"
+
+	// StateIdle is the state of worker handlers that did not get scheduled yet.
+	StateIdle = "idle"
+
+	// StateRunning is the state of worker handlers being executed right now.
+	StateRunning = "running"
+)
+
+// Status is the runtime snapshot of a single worker handler managed by a
+// worker engine.
+type Status struct {
+	// Active is the result of the last call to the Active scheduler primitive of
+	// the worker handler.
+	Active bool
+
+	// Cooler is the amount of time that the worker handler was scheduled to wait
+	// after completing its last cycle.
+	Cooler time.Duration
+
+	// Duration is the execution time of the last execution of the worker handler.
+	Duration time.Duration
+
+	// Engine is the name of the worker engine managing the worker handler, e.g.
+	// "parallel" or "sequence".
+	Engine string
+
+	// Error is the error of the last execution of the worker handler, if any.
+	Error error
+
+	// Index is the position of the worker handler within its stage.
+	Index int
+
+	// Name is the name of the worker handler as returned by handler.Name, or the
+	// node name for worker handlers of the *sequence.Worker engine.
+	Name string
+
+	// Paused is true if the worker handler got paused by an operator.
+	Paused bool
+
+	// Stage is the position of the stage within the graph of the worker engine
+	// executing the worker handler. The stage is always 0 for worker handlers
+	// executed by the *parallel.Worker engine.
+	Stage int
+
+	// State is the current state of the worker handler, e.g. StateRunning.
+	State string
+}
@@ -1,10 +1,32 @@
 package combined
 
+import (
+	"context"
+	"sync"
+)
+
 // Daemon executes the injected worker engines concurrently, each in their own
-// goroutine. Daemon itself does not block, so that it is the responsibility of
-// the calling goroutine to stop the programm to exit prematurely. Any signal
-// handling must then be injected directly into the underlying worker engines.
-func (w *Worker) Daemon() {
-	go w.par.Daemon()
-	go w.seq.Daemon()
+// goroutine. Daemon blocks until both worker engines returned, which happens
+// once the given context got cancelled, or once Worker.Stop got called. Any
+// signal handling may then be implemented by the caller using the given
+// context, e.g. via signal.NotifyContext.
+func (w *Worker) Daemon(ctx context.Context) {
+	var grp sync.WaitGroup
+	{
+		grp.Add(2)
+	}
+
+	go func() {
+		defer grp.Done()
+		w.par.Daemon(ctx)
+	}()
+
+	go func() {
+		defer grp.Done()
+		w.seq.Daemon(ctx)
+	}()
+
+	{
+		grp.Wait()
+	}
 }
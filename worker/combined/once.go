@@ -0,0 +1,50 @@
+package combined
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// RunOnce executes every active worker handler of the *parallel.Worker engine
+// exactly once, and the directed acyclic graph of the *sequence.Worker engine
+// exactly once, both concurrently. RunOnce blocks until both worker engines
+// returned, and reports the errors of both worker engines.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	var par error
+	var seq error
+	var grp sync.WaitGroup
+	{
+		grp.Add(2)
+	}
+
+	go func() {
+		defer grp.Done()
+		par = w.par.RunOnce(ctx)
+	}()
+
+	go func() {
+		defer grp.Done()
+		seq = w.seq.EnsureContext(ctx)
+	}()
+
+	{
+		grp.Wait()
+	}
+
+	if par != nil && seq != nil {
+		return tracer.Mask(errors.Join(par, seq))
+	}
+
+	if par != nil {
+		return tracer.Mask(par)
+	}
+
+	if seq != nil {
+		return tracer.Mask(seq)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package combined
+
+import (
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Status returns the runtime snapshot of all worker handlers managed by the
+// injected worker engines, starting with the *parallel.Worker engine.
+func (w *Worker) Status() []status.Status {
+	return append(w.par.Status(), w.seq.Status()...)
+}
+
+// Pause disables all worker handlers of the given name within both worker
+// engines.
+func (w *Worker) Pause(nam string) error {
+	return w.dispatch(nam, w.par.Pause, w.seq.Pause)
+}
+
+// Resume enables all paused worker handlers of the given name within both
+// worker engines.
+func (w *Worker) Resume(nam string) error {
+	return w.dispatch(nam, w.par.Resume, w.seq.Resume)
+}
+
+// Trigger executes all worker handlers of the given name right away within
+// both worker engines.
+func (w *Worker) Trigger(nam string) error {
+	return w.dispatch(nam, w.par.Trigger, w.seq.Trigger)
+}
+
+// dispatch calls the given operations of both worker engines, and returns
+// status.NotFoundError only if neither worker engine manages any worker
+// handler of the given name.
+func (w *Worker) dispatch(nam string, par func(string) error, seq func(string) error) error {
+	var fou bool
+
+	for _, x := range []func(string) error{par, seq} {
+		err := x(nam)
+		if status.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return tracer.Mask(err)
+		}
+
+		fou = true
+	}
+
+	if !fou {
+		return tracer.Mask(status.NotFoundError, tracer.Context{Key: "handler", Value: nam})
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package combined
+
+import (
+	"context"
+
+	"github.com/xh3b4sd/tracer"
+	"golang.org/x/sync/errgroup"
+)
+
+// Stop stops the injected worker engines concurrently and blocks until both
+// worker engines returned, or until the given context expired.
+func (w *Worker) Stop(ctx context.Context) error {
+	var grp errgroup.Group
+	{
+		grp = errgroup.Group{}
+	}
+
+	grp.Go(func() error {
+		return w.par.Stop(ctx)
+	})
+
+	grp.Go(func() error {
+		return w.seq.Stop(ctx)
+	})
+
+	{
+		err := grp.Wait()
+		if err != nil {
+			return tracer.Mask(err)
+		}
+	}
+
+	return nil
+}
@@ -1,26 +1,59 @@
 package parallel
 
 import (
+	"context"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/xh3b4sd/tracer"
 )
 
-func (w *Worker) Daemon() {
+// Daemon executes all injected worker handlers concurrently and blocks until
+// the given context is cancelled, or until Worker.Stop is called. Once stopped,
+// no new handler cycles are scheduled anymore, and Daemon waits for all
+// in-flight executions to finish within the configured grace period, before
+// returning.
+func (w *Worker) Daemon(ctx context.Context) {
 	w.log.Log(
 		"level", "info",
 		"message", "worker is executing tasks",
 		"pipelines", strconv.Itoa(len(w.han)),
 	)
 
+	// Derive the execution context for all worker handlers from the given
+	// context. Note that we detach the cancellation of the given context, so that
+	// in-flight executions may finish gracefully, even if the given context got
+	// cancelled. The execution context is only cancelled once all in-flight
+	// executions finished, or once the grace period expired.
+
+	var exe context.Context
+	var can context.CancelFunc
+	{
+		exe, can = context.WithCancel(context.WithoutCancel(ctx))
+	}
+
 	// Bootstrap a static worker pool of N goroutines, where N is the number of
 	// injected worker handlers. This parallel execution isolates worker specific
 	// failure domains. Each handler is executed along its own pipeline so that
 	// any handler specific runtime errors and execution delays cannot affect the
-	// execution of the other worker handlers.
+	// execution of the other worker handlers. The first execution of every
+	// handler may be delayed, so that not all handlers start in lockstep.
 
-	for _, h := range w.han {
-		go w.ensure(h)
+	var grp sync.WaitGroup
+	for i, h := range w.han {
+		grp.Add(1)
+		go func() {
+			defer grp.Done()
+			w.ensure(exe, h, w.rec[i], w.delay(h, i))
+		}()
+	}
+
+	// Consume the optional event channel, so that external events can trigger
+	// the execution of specific worker handlers right away.
+
+	if w.eve != nil {
+		go w.listen()
 	}
 
 	// Signal the worker engine's readiness by closing the internal ready channel.
@@ -36,11 +69,76 @@ func (w *Worker) Daemon() {
 	}
 
 	// Once the static worker pool created all necessary goroutines, we block
-	// Worker.Daemon forever as a long running process, so that we do not risk
-	// terminating the goroutines that we just bootstrapped.
+	// Worker.Daemon as a long running process, so that we do not risk
+	// terminating the goroutines that we just bootstrapped. We only stop blocking
+	// once we are asked to stop.
+
+	select {
+	case <-ctx.Done():
+	case <-w.stp:
+	}
+
+	{
+		w.stop()
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker is stopping tasks",
+		"grace", w.gra.String(),
+	)
+
+	// Wait for all in-flight executions to finish. If the grace period expires
+	// before all worker handlers returned, then we cancel the execution context
+	// and return anyway, so that Worker.Daemon never blocks the shutdown of the
+	// calling process indefinitely.
+
+	var don chan struct{}
+	{
+		don = make(chan struct{})
+	}
+
+	go func() {
+		grp.Wait()
+		close(don)
+	}()
+
+	var tim *time.Timer
+	{
+		tim = time.NewTimer(w.gra)
+	}
+
+	select {
+	case <-don:
+		tim.Stop()
+	case <-tim.C:
+		w.log.Log(
+			"level", "warning",
+			"message", "worker grace period expired",
+			"grace", w.gra.String(),
+		)
+	}
+
+	// Cancel the execution context before giving up any lease, so that neither
+	// abandoned executions nor their lease renewals continue once the leases got
+	// released. Releasing the leases waits for all lease renewals to stop.
+
+	{
+		can()
+	}
+
+	// Give up all leases held by this worker engine, so that other replicas can
+	// take over right away.
+
+	{
+		err := w.lea.Release(context.WithoutCancel(ctx))
+		if err != nil {
+			w.error(tracer.Mask(err))
+		}
+	}
 
 	{
-		select {}
+		close(w.don)
 	}
 }
 
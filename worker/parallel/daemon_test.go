@@ -2,6 +2,7 @@ package parallel
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -16,7 +17,12 @@ import (
 
 	"github.com/0xSplits/otelgo/recorder"
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/locker/memory"
 	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/schedule"
+	"github.com/0xSplits/workit/semaphore"
+	"github.com/0xSplits/workit/status"
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,11 +42,11 @@ func Test_Worker_Parallel_Daemon_active(t *testing.T) {
 	{
 		wor = New(Config{
 			Han: []handler.Cooler{
-				&activeHandler{sig, 3, true},
-				&activeHandler{sig, 4, true},
-				&activeHandler{sig, 5, false},
-				&activeHandler{sig, 6, false},
-				&activeHandler{sig, 7, true},
+				&activeHandler{sig, 3, true, "three"},
+				&activeHandler{sig, 4, true, "four"},
+				&activeHandler{sig, 5, false, "five"},
+				&activeHandler{sig, 6, false, "six"},
+				&activeHandler{sig, 7, true, "seven"},
 			},
 			Log: logger.Fake(),
 			Reg: registry.New(registry.Config{
@@ -56,7 +62,7 @@ func Test_Worker_Parallel_Daemon_active(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	var act []int
@@ -91,7 +97,7 @@ func Test_Worker_Parallel_Daemon_error(t *testing.T) {
 	{
 		wor = New(Config{
 			Han: []handler.Cooler{
-				&testHandler{coo: time.Hour},
+				&testHandler{coo: time.Hour, nam: "foo"},
 				&testHandler{coo: time.Hour, err: errors.New("test error")},
 			},
 			Log: logger.New(logger.Config{
@@ -111,7 +117,7 @@ func Test_Worker_Parallel_Daemon_error(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	{
@@ -139,7 +145,7 @@ func Test_Worker_Parallel_Daemon_filter(t *testing.T) {
 	{
 		wor = New(Config{
 			Han: []handler.Cooler{
-				&testHandler{coo: time.Hour},
+				&testHandler{coo: time.Hour, nam: "foo"},
 				&testHandler{coo: time.Hour, err: errors.New("test error")},
 			},
 			Log: logger.New(logger.Config{
@@ -160,7 +166,7 @@ func Test_Worker_Parallel_Daemon_filter(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	{
@@ -191,8 +197,8 @@ func Test_Worker_Parallel_Daemon_metrics(t *testing.T) {
 	{
 		wor = New(Config{
 			Han: []handler.Cooler{
-				&testHandler{coo: time.Hour},
-				&testHandler{coo: time.Hour},
+				&testHandler{coo: time.Hour, nam: "foo"},
+				&testHandler{coo: time.Hour, nam: "bar"},
 			},
 			Log: logger.Fake(),
 			Reg: registry.New(registry.Config{
@@ -223,7 +229,7 @@ func Test_Worker_Parallel_Daemon_metrics(t *testing.T) {
 		res = tesRes(url)
 	}
 
-	pat := `worker_handler_execution_total\{env="testing",handler="parallel",otel_scope_name="workit\.testing\.splits\.org",otel_scope_schema_url="",otel_scope_version="[^"]*",success="true"\} 2`
+	pat := `worker_handler_execution_total\{env="testing",handler="(foo|bar)",otel_scope_name="workit\.testing\.splits\.org",otel_scope_schema_url="",otel_scope_version="[^"]*",success="true"\} 1`
 	rgx := regexp.MustCompile(pat)
 
 	if rgx.MatchString(res) {
@@ -231,7 +237,7 @@ func Test_Worker_Parallel_Daemon_metrics(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	{
@@ -242,11 +248,241 @@ func Test_Worker_Parallel_Daemon_metrics(t *testing.T) {
 		res = tesRes(url)
 	}
 
+	if len(rgx.FindAllString(res, -1)) != 2 {
+		t.Fatal("expected", 2, "got", len(rgx.FindAllString(res, -1)))
+	}
+}
+
+// Test_Worker_Parallel_Daemon_cooler verifies that the *parallel.Worker records
+// the actual sleep between executions alongside the static labels of the
+// worker handler.
+func Test_Worker_Parallel_Daemon_cooler(t *testing.T) {
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var sig chan struct{}
+	{
+		sig = make(chan struct{}, 10)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&labelHandler{sig: sig},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// Trigger the worker handler while it is cooling down, so that its cooler
+	// got recorded once the second execution is in-flight.
+
+	{
+		tesSig(t, sig, true)
+	}
+
+	{
+		err := wor.Trigger("label")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		tesSig(t, sig, true)
+	}
+
+	pat := `worker_handler_cooler_duration_seconds_count\{env="testing",handler="label",[^}]*team="foo"\} 1`
+	rgx := regexp.MustCompile(pat)
+
+	var res string
+	{
+		res = tesRes(url)
+	}
+
 	if !rgx.MatchString(res) {
+		t.Fatalf("expected %s to match\n%s", pat, res)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_timeout verifies that the *parallel.Worker
+// abandons worker handlers exceeding their timeout, logs the timed out worker
+// handler and records the timeout as distinct failure reason.
+func Test_Worker_Parallel_Daemon_timeout(t *testing.T) {
+	var buf syncBuffer
+
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&testHandler{coo: time.Hour, inp: make(chan string), out: make(chan string)},
+			},
+			Log: logger.New(logger.Config{
+				Filter: logger.NewLevelFilter("error"),
+				Writer: &buf,
+			}),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Tim: 10 * time.Millisecond,
+		})
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	pat := `worker_handler_failure_total\{env="testing",handler="parallel",otel_scope_name="workit\.testing\.splits\.org",otel_scope_schema_url="",otel_scope_version="[^"]*",reason="timeout"\} 1`
+	rgx := regexp.MustCompile(pat)
+
+	exp := `"level":"error", "message":"worker execution failed", "stack":{"context":[{"key":"timeout","value":"10ms"},{"key":"handler","value":"parallel"}],"description":"The worker handler did not finish within its configured timeout.",`
+
+	// Poll the metrics endpoint and the log output until the timeout got
+	// recorded, because the worker handler times out asynchronously.
+
+	var met bool
+	var log bool
+	for range 100 {
+		{
+			met = rgx.MatchString(tesRes(url))
+			log = strings.Contains(buf.String(), exp)
+		}
+
+		if met && log {
+			break
+		}
+
+		{
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if !met {
+		t.Fatal("expected", true, "got", false)
+	}
+	if !log {
 		t.Fatal("expected", true, "got", false)
 	}
 }
 
+// Test_Worker_Parallel_Daemon_panic verifies that the *parallel.Worker
+// recovers panicking worker handlers, so that their pipelines keep running.
+func Test_Worker_Parallel_Daemon_panic(t *testing.T) {
+	var buf syncBuffer
+
+	var sig chan struct{}
+	{
+		sig = make(chan struct{})
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&panicHandler{sig: sig},
+			},
+			Log: logger.New(logger.Config{
+				Filter: logger.NewLevelFilter("error"),
+				Writer: &buf,
+			}),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// The panicking worker handler must be executed again and again, which
+	// proves that the worker handler's pipeline survived the recovered panics.
+
+	for range 3 {
+		select {
+		case <-sig:
+		case <-time.After(time.Second):
+			t.Fatal("test timeout")
+		}
+	}
+
+	{
+		exp := `"level":"error", "message":"worker execution failed", "stack":{"context":[{"key":"panic","value":"test panic"},{"key":"stack","value":"goroutine`
+		if !strings.Contains(buf.String(), exp) {
+			t.Fatal("expected", true, "got", false)
+		}
+	}
+
+	{
+		exp := `{"key":"handler","value":"parallel"}],"description":"The worker handler panicked during execution.",`
+		if !strings.Contains(buf.String(), exp) {
+			t.Fatal("expected", true, "got", false)
+		}
+	}
+}
+
 // Test_Worker_Parallel_Daemon_pipeline verifies the isolation of worker handler
 // failure domains so that the failure of one worker handler does not affect the
 // execution of another. For this test to be of any use, it must be executed
@@ -280,8 +516,8 @@ func Test_Worker_Parallel_Daemon_pipeline(t *testing.T) {
 	{
 		wor = New(Config{
 			Han: []handler.Cooler{
-				&testHandler{inp: in1, out: out, coo: time.Hour},
-				&testHandler{inp: in2, out: out, coo: 0},
+				&testHandler{inp: in1, out: out, coo: time.Hour, nam: "foo"},
+				&testHandler{inp: in2, out: out, coo: 0, nam: "bar"},
 			},
 			Log: logger.Fake(),
 			Reg: registry.New(registry.Config{
@@ -301,7 +537,7 @@ func Test_Worker_Parallel_Daemon_pipeline(t *testing.T) {
 	// <-wor.rdy unblocks and the test execution starts.
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	{
@@ -378,85 +614,875 @@ func Test_Worker_Parallel_Daemon_pipeline(t *testing.T) {
 	}
 }
 
-//
-//
-//
+// Test_Worker_Parallel_Daemon_stop verifies that the *parallel.Worker waits for
+// in-flight executions when being stopped, and that the execution context is
+// only cancelled once the grace period expired.
+func Test_Worker_Parallel_Daemon_stop(t *testing.T) {
+	testCases := []struct {
+		blo bool
+		gra time.Duration
+		err error
+	}{
+		// Case 000, in-flight execution finishes within the grace period
+		{
+			blo: false,
+			gra: time.Second,
+			err: nil,
+		},
+		// Case 001, in-flight execution exceeds the grace period
+		{
+			blo: true,
+			gra: 10 * time.Millisecond,
+			err: context.Canceled,
+		},
+	}
 
-func tesRes(url string) string {
-	var err error
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sig chan struct{}
+			var rel chan struct{}
+			var out chan error
+			{
+				sig = make(chan struct{})
+				rel = make(chan struct{})
+				out = make(chan error, 1)
+			}
+
+			var wor *Worker
+			{
+				wor = New(Config{
+					Gra: tc.gra,
+					Han: []handler.Cooler{
+						&stopHandler{sig: sig, rel: rel, out: out, blo: tc.blo},
+					},
+					Log: logger.Fake(),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+				})
+			}
+
+			var ctx context.Context
+			var can context.CancelFunc
+			{
+				ctx, can = context.WithCancel(context.Background())
+			}
+
+			var don chan struct{}
+			{
+				don = make(chan struct{})
+			}
+
+			go func() {
+				wor.Daemon(ctx)
+				close(don)
+			}()
+
+			// Wait for the worker handler to be in-flight and cancel the daemon
+			// context while the worker handler is still executing. Only then we
+			// release the worker handler.
+
+			{
+				<-sig
+			}
+
+			{
+				can()
+				close(rel)
+			}
+
+			select {
+			case <-don:
+			case <-time.After(time.Second):
+				t.Fatal("test timeout")
+			}
+
+			{
+				err := wor.Stop(context.Background())
+				if err != nil {
+					t.Fatal("expected", nil, "got", err)
+				}
+			}
+
+			{
+				err := <-out
+				if !errors.Is(err, tc.err) {
+					t.Fatal("expected", tc.err, "got", err)
+				}
+			}
+		})
+	}
+}
 
-	var res *http.Response
+// Test_Worker_Parallel_Daemon_release verifies that the *parallel.Worker does
+// not hold any lease anymore once Worker.Daemon returned, even if an abandoned
+// execution is still running after the grace period expired.
+func Test_Worker_Parallel_Daemon_release(t *testing.T) {
+	var sto *memory.Store
 	{
-		res, err = http.Get(url)
-		if err != nil {
-			panic(err)
-		}
+		sto = &memory.Store{}
 	}
 
+	var sig chan struct{}
+	var rel chan struct{}
+	var out chan error
 	{
-		defer res.Body.Close() // nolint:errcheck
+		sig = make(chan struct{})
+		rel = make(chan struct{})
+		out = make(chan error, 1)
 	}
 
-	var byt []byte
+	var wor *Worker
 	{
-		byt, err = io.ReadAll(res.Body)
-		if err != nil {
-			panic(err)
-		}
+		wor = New(Config{
+			Gra: 10 * time.Millisecond,
+			Han: []handler.Cooler{
+				&stopHandler{sig: sig, rel: rel, out: out},
+			},
+			Loc: memory.New(memory.Config{Own: "one", Sto: sto}),
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Ren: time.Millisecond,
+		})
 	}
 
-	if res.StatusCode != http.StatusOK {
-		panic(fmt.Sprint("expected", http.StatusOK, "got", res.Status))
+	{
+		go wor.Daemon(context.Background())
 	}
 
-	return string(byt)
-}
+	// Stop the worker engine while its worker handler is in flight, without ever
+	// releasing the worker handler, so that its execution gets abandoned.
 
-func tesSer(reg *prometheus.Registry) (*httptest.Server, string) {
-	var rtr *mux.Router
 	{
-		rtr = mux.NewRouter()
+		<-sig
 	}
 
 	{
-		rtr.NewRoute().Methods("GET").Path("/metrics").Handler(promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
 	}
 
-	var ser *httptest.Server
+	// Give any lease renewal the chance to acquire the lease again, before
+	// verifying that another replica can take over the lease.
+
 	{
-		ser = httptest.NewServer(rtr)
+		time.Sleep(20 * time.Millisecond)
 	}
 
-	return ser, ser.URL + "/metrics"
-}
-
-//
-//
-//
-
-type activeHandler struct {
-	sig chan int
-	num int
-	act bool
-}
-
-func (h *activeHandler) Active() bool {
-	return h.act
-}
+	{
+		acq, err := memory.New(memory.Config{Own: "two", Sto: sto}).Acquire(context.Background(), "parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		if !acq {
+			t.Fatal("expected", true, "got", false)
+		}
+	}
 
-func (h *activeHandler) Cooler() time.Duration {
-	return time.Hour
+	{
+		close(rel)
+		<-out
+	}
 }
 
-func (h *activeHandler) Ensure() error {
+// Test_Worker_Parallel_Daemon_schedule verifies that the *parallel.Worker
+// executes worker handlers on their strict schedule, and that the next
+// activation time of those worker handlers is observable.
+func Test_Worker_Parallel_Daemon_schedule(t *testing.T) {
+	var sig chan struct{}
 	{
-		h.sig <- h.num
+		sig = make(chan struct{}, 10)
 	}
 
-	return nil
-}
-
-//
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&scheduleHandler{sig: sig},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// The cooler of the scheduled worker handler is one hour. Receiving multiple
+	// executions within a second proves that the strict schedule is honored.
+
+	for range 3 {
+		select {
+		case <-sig:
+		case <-time.After(time.Second):
+			t.Fatal("test timeout")
+		}
+	}
+
+	{
+		nxt := wor.Next("parallel")
+		if nxt.IsZero() {
+			t.Fatal("expected", "next activation time", "got", nxt)
+		}
+		if nxt.Sub(time.Now()) > 20*time.Millisecond {
+			t.Fatal("expected", "next activation time within 20ms", "got", nxt)
+		}
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_health verifies that the *parallel.Worker records
+// the execution state of its worker handlers for health reporting.
+func Test_Worker_Parallel_Daemon_health(t *testing.T) {
+	var hea *health.Health
+	{
+		hea = health.New(health.Config{})
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&testHandler{coo: time.Millisecond, err: errors.New("test error")},
+			},
+			Hea: hea,
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	var rep health.Report
+	for {
+		rep = hea.Report()
+		if len(rep.Status) == 1 && rep.Status[0].Failures >= 3 {
+			break
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if !rep.Healthy {
+		t.Fatal("expected", true, "got", rep.Healthy)
+	}
+	if rep.Status[0].Name != "parallel" {
+		t.Fatal("expected", "parallel", "got", rep.Status[0].Name)
+	}
+	if !rep.Status[0].Ready {
+		t.Fatal("expected", true, "got", rep.Status[0].Ready)
+	}
+	if rep.Status[0].LastStart.IsZero() {
+		t.Fatal("expected", "last start", "got", rep.Status[0].LastStart)
+	}
+	if !rep.Status[0].LastSuccess.IsZero() {
+		t.Fatal("expected", "no last success", "got", rep.Status[0].LastSuccess)
+	}
+	if rep.Status[0].LastError == nil || !strings.Contains(rep.Status[0].LastError.Error(), "test error") {
+		t.Fatal("expected", "test error", "got", rep.Status[0].LastError)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_leader verifies that only a single replica of
+// the *parallel.Worker executes any given worker handler, if leader election
+// is enabled, and that the lease is released once the worker engine stopped.
+func Test_Worker_Parallel_Daemon_leader(t *testing.T) {
+	var sto *memory.Store
+	{
+		sto = &memory.Store{}
+	}
+
+	var han []*leaderHandler
+	var wor []*Worker
+	for _, x := range []string{"one", "two"} {
+		h := &leaderHandler{}
+
+		w := New(Config{
+			Han: []handler.Cooler{h},
+			Loc: memory.New(memory.Config{Own: x, Sto: sto}),
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+
+		han = append(han, h)
+		wor = append(wor, w)
+	}
+
+	{
+		go wor[0].Daemon(context.Background())
+	}
+
+	// Wait for the first replica to execute its worker handler a couple of times
+	// before starting the second replica, so that the first replica is
+	// guaranteed to hold the lease.
+
+	for han[0].count() < 3 {
+		time.Sleep(time.Millisecond)
+	}
+
+	{
+		go wor[1].Daemon(context.Background())
+	}
+
+	{
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if han[1].count() != 0 {
+		t.Fatal("expected", 0, "got", han[1].count())
+	}
+
+	// Once the first replica stopped, its lease is released, so that the second
+	// replica takes over.
+
+	{
+		err := wor[0].Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	for han[1].count() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	{
+		err := wor[1].Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_status verifies that the *parallel.Worker
+// exposes the runtime state of its worker handlers, and that worker handlers
+// can be paused, resumed and triggered at runtime.
+func Test_Worker_Parallel_Daemon_status(t *testing.T) {
+	var sig chan struct{}
+	{
+		sig = make(chan struct{}, 10)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&triggerHandler{sig: sig},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		sta := wor.Status()
+		if sta[0].State != status.StateIdle {
+			t.Fatal("expected", status.StateIdle, "got", sta[0].State)
+		}
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// The first execution happens right away. Any further execution only
+	// happens if triggered, because the cooler is one hour.
+
+	{
+		tesSig(t, sig, true)
+		tesSta(t, wor, status.StateCooling)
+	}
+
+	{
+		sta := wor.Status()
+		if sta[0].Name != "parallel" {
+			t.Fatal("expected", "parallel", "got", sta[0].Name)
+		}
+		if sta[0].Cooler != time.Hour {
+			t.Fatal("expected", time.Hour, "got", sta[0].Cooler)
+		}
+		if !sta[0].Active {
+			t.Fatal("expected", true, "got", sta[0].Active)
+		}
+	}
+
+	{
+		err := wor.Trigger("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesSig(t, sig, true)
+	}
+
+	// Paused worker handlers are disabled, even if triggered.
+
+	{
+		err := wor.Pause("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		err = wor.Trigger("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesSta(t, wor, status.StateDisabled)
+		tesSig(t, sig, false)
+	}
+
+	{
+		err := wor.Resume("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		err = wor.Trigger("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+		tesSig(t, sig, true)
+	}
+
+	{
+		err := wor.Trigger("unknown")
+		if !status.IsNotFound(err) {
+			t.Fatal("expected", status.NotFoundError, "got", err)
+		}
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_trigger verifies that the *parallel.Worker
+// executes worker handlers right away when triggered, also via the event
+// channel, and that triggers received during an in-flight execution are
+// coalesced into a single execution.
+func Test_Worker_Parallel_Daemon_trigger(t *testing.T) {
+	var eve chan string
+	var sig chan struct{}
+	var rel chan struct{}
+	{
+		eve = make(chan string)
+		sig = make(chan struct{}, 10)
+		rel = make(chan struct{}, 10)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Eve: eve,
+			Han: []handler.Cooler{
+				&triggerHandler{sig: sig, rel: rel},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// Wait for the first execution to be in-flight and trigger the worker
+	// handler multiple times in the meantime.
+
+	{
+		tesSig(t, sig, true)
+	}
+
+	for range 5 {
+		err := wor.Trigger("parallel")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	// Releasing the first execution results in exactly one more execution,
+	// because all triggers got coalesced.
+
+	{
+		rel <- struct{}{}
+		tesSig(t, sig, true)
+		rel <- struct{}{}
+		tesSig(t, sig, false)
+	}
+
+	// The worker handler can be triggered via the event channel once it is
+	// cooling down.
+
+	{
+		eve <- "parallel"
+		tesSig(t, sig, true)
+		rel <- struct{}{}
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+//
+//
+//
+
+// Test_Worker_Parallel_Daemon_delay verifies that the *parallel.Worker waits
+// for the initial delay before executing a worker handler for the first time.
+func Test_Worker_Parallel_Daemon_delay(t *testing.T) {
+	var sig chan struct{}
+	{
+		sig = make(chan struct{}, 10)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Del: 50 * time.Millisecond,
+			Han: []handler.Cooler{
+				&triggerHandler{sig: sig},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	{
+		<-wor.rdy
+	}
+
+	select {
+	case <-sig:
+		t.Fatal("expected", "no execution", "got", "execution")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	{
+		tesSig(t, sig, true)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Parallel_Daemon_limit verifies that the *parallel.Worker bounds
+// the number of concurrent worker handler executions, both globally and per
+// resource group.
+func Test_Worker_Parallel_Daemon_limit(t *testing.T) {
+	var cou *limitCounter
+	var gro *limitCounter
+	{
+		cou = &limitCounter{}
+		gro = &limitCounter{}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&limitHandler{cou: cou, nam: "foo"},
+				&limitHandler{cou: cou, nam: "bar"},
+				&limitHandler{cou: cou, gro: gro, grp: "rpc", nam: "baz"},
+				&limitHandler{cou: cou, gro: gro, grp: "rpc", nam: "qux"},
+			},
+			Log: logger.Fake(),
+			Max: 2,
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Sem: semaphore.New(semaphore.Config{
+				Lim: map[string]int{
+					"rpc": 1,
+				},
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	for gro.total() < 5 || cou.total() < 20 {
+		time.Sleep(time.Millisecond)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if cou.peak() > 2 {
+		t.Fatal("expected", 2, "got", cou.peak())
+	}
+	if gro.peak() != 1 {
+		t.Fatal("expected", 1, "got", gro.peak())
+	}
+}
+
+// Test_Worker_Parallel_Daemon_limit_abandoned verifies that the slots of
+// resource groups are only freed once abandoned executions returned, so that
+// abandoned executions still running in the background cannot exceed the
+// concurrency limits of their resource group.
+func Test_Worker_Parallel_Daemon_limit_abandoned(t *testing.T) {
+	var gro *limitCounter
+	{
+		gro = &limitCounter{}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&abandonHandler{gro: gro, nam: "foo"},
+				&limitHandler{cou: &limitCounter{}, gro: gro, grp: "rpc", nam: "bar"},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Sem: semaphore.New(semaphore.Config{
+				Lim: map[string]int{
+					"rpc": 1,
+				},
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	for gro.total() < 10 {
+		time.Sleep(time.Millisecond)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if gro.peak() != 1 {
+		t.Fatal("expected", 1, "got", gro.peak())
+	}
+}
+
+func tesRes(url string) string {
+	var err error
+
+	var res *http.Response
+	{
+		res, err = http.Get(url)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	{
+		defer res.Body.Close() // nolint:errcheck
+	}
+
+	var byt []byte
+	{
+		byt, err = io.ReadAll(res.Body)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		panic(fmt.Sprint("expected", http.StatusOK, "got", res.Status))
+	}
+
+	return string(byt)
+}
+
+func tesSig(t *testing.T, sig chan struct{}, exp bool) {
+	t.Helper()
+
+	select {
+	case <-sig:
+		if !exp {
+			t.Fatal("expected", "no execution", "got", "execution")
+		}
+	case <-time.After(100 * time.Millisecond):
+		if exp {
+			t.Fatal("expected", "execution", "got", "test timeout")
+		}
+	}
+}
+
+func tesSta(t *testing.T, wor *Worker, exp string) {
+	t.Helper()
+
+	for range 100 {
+		if wor.Status()[0].State == exp {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("expected", exp, "got", wor.Status()[0].State)
+}
+
+func tesSer(reg *prometheus.Registry) (*httptest.Server, string) {
+	var rtr *mux.Router
+	{
+		rtr = mux.NewRouter()
+	}
+
+	{
+		rtr.NewRoute().Methods("GET").Path("/metrics").Handler(promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	}
+
+	var ser *httptest.Server
+	{
+		ser = httptest.NewServer(rtr)
+	}
+
+	return ser, ser.URL + "/metrics"
+}
+
+//
+//
+//
+
+type activeHandler struct {
+	sig chan int
+	num int
+	act bool
+	nam string
+}
+
+func (h *activeHandler) Active() bool {
+	return h.act
+}
+
+func (h *activeHandler) Name() string {
+	return h.nam
+}
+
+func (h *activeHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *activeHandler) Ensure() error {
+	{
+		h.sig <- h.num
+	}
+
+	return nil
+}
+
+//
 //
 //
 
@@ -464,6 +1490,7 @@ type testHandler struct {
 	coo time.Duration
 	err error
 	inp chan string
+	nam string
 	out chan string
 }
 
@@ -471,6 +1498,10 @@ func (h *testHandler) Active() bool {
 	return true
 }
 
+func (h *testHandler) Name() string {
+	return h.nam
+}
+
 // Cooler simply returns the underlying cooldown duration, defining how long
 // this handler ought to sleep before being scheduled again.
 func (h *testHandler) Cooler() time.Duration {
@@ -504,8 +1535,294 @@ func (h *testHandler) Ensure() error {
 //
 //
 
+type labelHandler struct {
+	sig chan struct{}
+}
+
+func (h *labelHandler) Active() bool {
+	return true
+}
+
+func (h *labelHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *labelHandler) Ensure() error {
+	h.sig <- struct{}{}
+	return nil
+}
+
+func (h *labelHandler) Labels() map[string]string {
+	return map[string]string{"team": "foo"}
+}
+
+func (h *labelHandler) Name() string {
+	return "label"
+}
+
+//
+//
+//
+
+type leaderHandler struct {
+	cou int
+	mut sync.Mutex
+}
+
+func (h *leaderHandler) Active() bool {
+	return true
+}
+
+func (h *leaderHandler) Cooler() time.Duration {
+	return time.Millisecond
+}
+
+func (h *leaderHandler) Ensure() error {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	h.cou++
+
+	return nil
+}
+
+func (h *leaderHandler) count() int {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+
+	return h.cou
+}
+
+type abandonHandler struct {
+	gro *limitCounter
+	nam string
+}
+
+func (h *abandonHandler) Active() bool {
+	return true
+}
+
+func (h *abandonHandler) Name() string {
+	return h.nam
+}
+
+func (h *abandonHandler) Cooler() time.Duration {
+	return time.Millisecond
+}
+
+// Ensure keeps running beyond its timeout, so that its execution gets
+// abandoned while still occupying the slot of its resource group.
+func (h *abandonHandler) Ensure() error {
+	h.gro.inc()
+	defer h.gro.dec()
+
+	time.Sleep(20 * time.Millisecond)
+
+	return nil
+}
+
+func (h *abandonHandler) Group() string {
+	return "rpc"
+}
+
+func (h *abandonHandler) Timeout() time.Duration {
+	return 2 * time.Millisecond
+}
+
+type limitCounter struct {
+	cur int
+	mut sync.Mutex
+	pea int
+	tot int
+}
+
+func (c *limitCounter) inc() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.cur++
+	c.tot++
+	c.pea = max(c.pea, c.cur)
+}
+
+func (c *limitCounter) dec() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.cur--
+}
+
+func (c *limitCounter) peak() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.pea
+}
+
+func (c *limitCounter) total() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.tot
+}
+
+type limitHandler struct {
+	cou *limitCounter
+	gro *limitCounter
+	grp string
+	nam string
+}
+
+func (h *limitHandler) Active() bool {
+	return true
+}
+
+func (h *limitHandler) Name() string {
+	return h.nam
+}
+
+func (h *limitHandler) Cooler() time.Duration {
+	return time.Millisecond
+}
+
+func (h *limitHandler) Ensure() error {
+	h.cou.inc()
+	defer h.cou.dec()
+
+	if h.gro != nil {
+		h.gro.inc()
+		defer h.gro.dec()
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	return nil
+}
+
+func (h *limitHandler) Group() string {
+	return h.grp
+}
+
+type panicHandler struct {
+	sig chan struct{}
+}
+
+func (h *panicHandler) Active() bool {
+	return true
+}
+
+func (h *panicHandler) Cooler() time.Duration {
+	return 0
+}
+
+func (h *panicHandler) Ensure() error {
+	{
+		h.sig <- struct{}{}
+	}
+
+	panic("test panic")
+}
+
+//
+//
+//
+
+// stopHandler signals once it is in-flight and waits to be released. The
+// handler returns after its release if blo is false. Otherwise the handler
+// blocks until its execution context got cancelled. Either way, the state of
+// the execution context is reported upon return.
+type stopHandler struct {
+	sig chan struct{}
+	rel chan struct{}
+	out chan error
+	blo bool
+}
+
+func (h *stopHandler) Active() bool {
+	return true
+}
+
+func (h *stopHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *stopHandler) Ensure() error {
+	return nil
+}
+
+func (h *stopHandler) EnsureContext(ctx context.Context) error {
+	{
+		h.sig <- struct{}{}
+		<-h.rel
+	}
+
+	if h.blo {
+		<-ctx.Done()
+	}
+
+	{
+		h.out <- ctx.Err()
+	}
+
+	return nil
+}
+
+//
+//
+//
+
 // syncBuffer is needed to synchronize the concurrent io.Writer operations
 // related to the logger interface that we are testing against.
+type scheduleHandler struct {
+	sig chan struct{}
+}
+
+func (h *scheduleHandler) Active() bool {
+	return true
+}
+
+func (h *scheduleHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *scheduleHandler) Ensure() error {
+	select {
+	case h.sig <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+func (h *scheduleHandler) Schedule() schedule.Interface {
+	return schedule.New(schedule.Config{
+		Dur: 10 * time.Millisecond,
+	})
+}
+
+type triggerHandler struct {
+	rel chan struct{}
+	sig chan struct{}
+}
+
+func (h *triggerHandler) Active() bool {
+	return true
+}
+
+func (h *triggerHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *triggerHandler) Ensure() error {
+	h.sig <- struct{}{}
+
+	if h.rel != nil {
+		<-h.rel
+	}
+
+	return nil
+}
+
 type syncBuffer struct {
 	mu  sync.Mutex
 	buf bytes.Buffer
@@ -1,32 +1,195 @@
 package parallel
 
 import (
+	"context"
 	"time"
 
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/status"
 	"github.com/xh3b4sd/tracer"
 )
 
-func (w *Worker) ensure(han handler.Interface) {
+func (w *Worker) ensure(ctx context.Context, han handler.Interface, rec *record, del time.Duration) {
+	// Execute the worker handler on its strict schedule, if it defines one.
+	// Otherwise the worker handler sleeps for its cooler after every execution.
+	// Note that the initial delay does not apply to strict schedules.
+
+	sch := han.Schedule()
+	if sch != nil {
+		w.schedule(ctx, han, rec, sch)
+		return
+	}
+
+	// Schedule the first cycle of this worker handler once its initial delay
+	// passed, which is right away by default. Triggered executions during the
+	// initial delay do not affect the initial delay.
+
+	{
+		w.hea.Cooling(rec.nam, del)
+		w.state(rec, func(r *record) { r.coo = del })
+	}
+
+	if del > 0 && w.wait(ctx, han, rec, time.Now().Add(del)) {
+		return
+	}
+
 	for {
-		// Execute the worker handler if it declares itself to be active. Also log
-		// any runtime error of this handler's business logic if the configured
-		// error matcher permits it. Note that any error caught here may never
-		// originate from the worker engine's internal metric registry.
-
-		if han.Active() {
-			err := han.Ensure()
-			if err != nil && !w.reg.Log(err) {
-				w.error(tracer.Mask(err, tracer.Context{Key: "handler", Value: handler.Name(han.Unwrap())}))
-			}
+		// Do not schedule another cycle for this worker handler if the worker
+		// engine is about to stop.
+
+		select {
+		case <-w.stp:
+			return
+		default:
+		}
+
+		{
+			w.cycle(ctx, han, rec)
 		}
 
 		// Sleep for the given duration after this worker handler has been executed.
 		// This specific cycle repeats again for the given worker handler only,
-		// after the sleep below is over.
+		// after the sleep below is over. The sleep is interrupted if the worker
+		// engine is about to stop, or if the worker handler got triggered.
 
+		var coo time.Duration
 		{
-			time.Sleep(han.Cooler())
+			coo = w.jitter(han, han.Cooler())
+		}
+
+		{
+			w.hea.Cooling(rec.nam, coo)
+			w.state(rec, func(r *record) { r.coo = coo })
+			w.obs.OnCoolerStart(rec.nam, coo)
+		}
+
+		var sta time.Time
+		var tim *time.Timer
+		{
+			sta = time.Now()
+			tim = time.NewTimer(coo)
+		}
+
+		select {
+		case <-w.stp:
+			tim.Stop()
+			return
+		case <-rec.wak:
+			tim.Stop()
+		case <-tim.C:
+		}
+
+		{
+			w.insCoo(rec, time.Since(sta))
 		}
 	}
 }
+
+// cycle executes the worker handler like run does, and logs any runtime error
+// of this handler's business logic if the configured error matcher permits it.
+// Note that any error caught here may never originate from the worker engine's
+// internal metric registry.
+func (w *Worker) cycle(ctx context.Context, han handler.Interface, rec *record) {
+	err := w.run(ctx, han, rec)
+	if err != nil && !w.reg.Log(err) {
+		w.error(tracer.Mask(err))
+	}
+}
+
+// run executes the worker handler if it declares itself to be active, if it is
+// not paused, and if this worker engine holds the lease of the worker handler,
+// if any. The execution waits for the concurrency limits of the worker handler,
+// if any. The runtime state of the worker handler is recorded along the way.
+func (w *Worker) run(ctx context.Context, han handler.Interface, rec *record) error {
+	var pau bool
+	w.state(rec, func(r *record) { pau = r.pau })
+
+	if pau {
+		w.state(rec, func(r *record) { r.sta = status.StateDisabled })
+		w.obs.OnSkip(rec.nam)
+		return nil
+	}
+
+	var act bool
+	{
+		act = han.Active()
+	}
+
+	w.state(rec, func(r *record) { r.act = act })
+
+	if !act {
+		w.state(rec, func(r *record) { r.sta = status.StateDisabled })
+		w.obs.OnSkip(rec.nam)
+		return nil
+	}
+
+	// Always log lease errors, regardless of the configured error matcher,
+	// because they originate from the worker engine's leader election, not from
+	// the business logic of the worker handler.
+
+	lea, err := w.leader(ctx, han)
+	if err != nil {
+		w.error(tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam}))
+	}
+	if !lea {
+		w.state(rec, func(r *record) { r.sta = status.StateCooling })
+		w.obs.OnSkip(rec.nam)
+		return nil
+	}
+
+	// Wait for the concurrency limits to permit the execution of this worker
+	// handler. Waiting is aborted if the given context got cancelled, or if the
+	// worker engine is about to stop, in which case this execution fails.
+
+	rel, err := w.acquire(ctx, han)
+	if err != nil {
+		return tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam})
+	}
+
+	{
+		defer rel()
+	}
+
+	var sta time.Time
+	{
+		sta = time.Now()
+	}
+
+	w.state(rec, func(r *record) { r.sta = status.StateRunning })
+	w.obs.OnStart(rec.nam)
+
+	// Keep renewing the lease of the worker handler during its execution, if
+	// any, so that the execution gets cancelled once the lease got lost.
+
+	hct, hol := w.lea.Hold(ctx, w.locker(han), rec.nam)
+
+	{
+		err = w.exe.Execute(hct, rec.nam, han)
+	}
+
+	if los := hol(); los != nil {
+		err = los
+	}
+
+	var dur time.Duration
+	{
+		dur = time.Since(sta)
+	}
+
+	w.state(rec, func(r *record) {
+		r.dur = dur
+		r.err = err
+		r.sta = status.StateCooling
+	})
+
+	if err != nil {
+		w.obs.OnError(rec.nam, dur, err)
+		return tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam})
+	}
+
+	{
+		w.obs.OnSuccess(rec.nam, dur)
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package parallel
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+)
+
+// delay returns the initial delay of the given worker handler at the given
+// position within the list of worker handlers. The initial delay of the worker
+// handler takes precedence over the initial delay of this worker engine. The
+// deterministic stagger of the given position is added to the initial delay,
+// before random jitter is applied to the sum of both.
+func (w *Worker) delay(han handler.Interface, ind int) time.Duration {
+	var del time.Duration
+	{
+		del = han.Delay()
+	}
+
+	if del == 0 {
+		del = w.del
+	}
+
+	if del < 0 {
+		del = 0
+	}
+
+	if w.spr > 0 {
+		del += w.spr * time.Duration(ind) / time.Duration(len(w.han))
+	}
+
+	return w.jitter(han, del)
+}
+
+// jitter returns the given duration modified by the random jitter of the given
+// worker handler. The jitter of the worker handler takes precedence over the
+// jitter of this worker engine.
+func (w *Worker) jitter(han handler.Interface, dur time.Duration) time.Duration {
+	var jit float64
+	{
+		jit = han.Jitter()
+	}
+
+	if jit == 0 {
+		jit = w.jit
+	}
+
+	return jitter(dur, jit)
+}
+
+// jitter returns the given duration modified by a random percentage between
+// -jit and +jit. The given duration is returned as is if the given fraction is
+// not positive.
+func jitter(dur time.Duration, jit float64) time.Duration {
+	if dur <= 0 || jit <= 0 {
+		return dur
+	}
+
+	return time.Duration(float64(dur) * (1 + jit*(2*rand.Float64()-1)))
+}
@@ -0,0 +1,179 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Worker_Parallel_delay(t *testing.T) {
+	testCases := []struct {
+		del time.Duration
+		spr time.Duration
+		han time.Duration
+		ind int
+		exp time.Duration
+	}{
+		// Case 000, no initial delay by default
+		{
+			ind: 3,
+			exp: 0,
+		},
+		// Case 001, initial delay of the worker engine
+		{
+			del: time.Second,
+			ind: 3,
+			exp: time.Second,
+		},
+		// Case 002, stagger spreads 4 handlers evenly across the window
+		{
+			spr: time.Minute,
+			ind: 3,
+			exp: 45 * time.Second,
+		},
+		// Case 003, stagger is added to the initial delay
+		{
+			del: time.Second,
+			spr: time.Minute,
+			ind: 2,
+			exp: 31 * time.Second,
+		},
+		// Case 004, initial delay of the worker handler takes precedence
+		{
+			del: time.Second,
+			han: 5 * time.Second,
+			ind: 0,
+			exp: 5 * time.Second,
+		},
+		// Case 005, worker handler disables the initial delay, but not the stagger
+		{
+			del: time.Second,
+			spr: time.Minute,
+			han: -1,
+			ind: 1,
+			exp: 15 * time.Second,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var han []handler.Cooler
+			for j := range 4 {
+				han = append(han, &jitterHandler{del: tc.han, nam: fmt.Sprintf("jitter%d", j)})
+			}
+
+			var wor *Worker
+			{
+				wor = New(Config{
+					Del: tc.del,
+					Han: han,
+					Log: logger.Fake(),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+					Spr: tc.spr,
+				})
+			}
+
+			var del time.Duration
+			{
+				del = wor.delay(wor.han[tc.ind], tc.ind)
+			}
+
+			if dif := cmp.Diff(tc.exp, del); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+func Test_Worker_Parallel_jitter(t *testing.T) {
+	testCases := []struct {
+		dur time.Duration
+		jit float64
+		low time.Duration
+		hig time.Duration
+	}{
+		// Case 000, no jitter
+		{
+			dur: time.Second,
+			jit: 0,
+			low: time.Second,
+			hig: time.Second,
+		},
+		// Case 001, disabled jitter
+		{
+			dur: time.Second,
+			jit: -1,
+			low: time.Second,
+			hig: time.Second,
+		},
+		// Case 002, +-10% jitter
+		{
+			dur: time.Second,
+			jit: 0.1,
+			low: 900 * time.Millisecond,
+			hig: 1100 * time.Millisecond,
+		},
+		// Case 003, zero durations remain zero
+		{
+			dur: 0,
+			jit: 0.5,
+			low: 0,
+			hig: 0,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			for range 100 {
+				dur := jitter(tc.dur, tc.jit)
+				if dur < tc.low || dur > tc.hig {
+					t.Fatal("expected", fmt.Sprintf("[%s, %s]", tc.low, tc.hig), "got", dur)
+				}
+			}
+		})
+	}
+}
+
+type jitterHandler struct {
+	del time.Duration
+	jit float64
+	nam string
+}
+
+func (h *jitterHandler) Active() bool {
+	return true
+}
+
+func (h *jitterHandler) Cooler() time.Duration {
+	return time.Minute
+}
+
+func (h *jitterHandler) Delay() time.Duration {
+	return h.del
+}
+
+func (h *jitterHandler) Ensure() error {
+	return nil
+}
+
+func (h *jitterHandler) Jitter() float64 {
+	return h.jit
+}
+
+func (h *jitterHandler) Name() string {
+	return h.nam
+}
@@ -0,0 +1,41 @@
+package parallel
+
+import (
+	"context"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/locker"
+	"github.com/xh3b4sd/tracer"
+)
+
+// leader returns whether this worker engine holds the lease of the given worker
+// handler. Worker handlers without any locker are always executed.
+func (w *Worker) leader(ctx context.Context, han handler.Interface) (bool, error) {
+	var loc locker.Interface
+	{
+		loc = w.locker(han)
+	}
+
+	if loc == nil {
+		return true, nil
+	}
+
+	lea, err := w.lea.Ensure(ctx, loc, handler.Name(han.Unwrap()))
+	if err != nil {
+		return false, tracer.Mask(err)
+	}
+
+	return lea, nil
+}
+
+// locker returns the locker guarding the given worker handler, if any. The
+// locker of the worker handler takes precedence over the locker of this worker
+// engine.
+func (w *Worker) locker(han handler.Interface) locker.Interface {
+	loc := han.Locker()
+	if loc != nil {
+		return loc
+	}
+
+	return w.loc
+}
@@ -0,0 +1,106 @@
+package parallel
+
+import (
+	"context"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// acquire blocks until the given worker handler may be executed within the
+// concurrency limits of its resource group, if any, and of this worker engine,
+// if any. Waiting is aborted once this worker engine is about to stop. The
+// returned function must be called once the execution of the given worker
+// handler finished, so that the acquired slots are freed again, as soon as any
+// abandoned execution of the given worker handler returned as well.
+func (w *Worker) acquire(ctx context.Context, han handler.Interface) (func(), error) {
+	var can context.CancelFunc
+	{
+		ctx, can = context.WithCancel(ctx)
+	}
+
+	{
+		defer can()
+	}
+
+	go func() {
+		select {
+		case <-w.stp:
+			can()
+		case <-ctx.Done():
+		}
+	}()
+
+	// Acquire the slot of the resource group first, so that the slots of this
+	// worker engine are only occupied by worker handlers that can be executed
+	// right away. Acquiring in the same order everywhere prevents deadlocks.
+
+	var grp string
+	{
+		grp = han.Group()
+	}
+
+	if grp != "" {
+		err := w.sem.Acquire(ctx, grp)
+		if err != nil {
+			return nil, tracer.Mask(err)
+		}
+	}
+
+	if w.lim != nil {
+		select {
+		case w.lim <- struct{}{}:
+		case <-ctx.Done():
+			if grp != "" {
+				w.sem.Release(grp)
+			}
+
+			return nil, tracer.Mask(ctx.Err())
+		}
+	}
+
+	rel := func() {
+		if w.lim != nil {
+			<-w.lim
+		}
+
+		if grp != "" {
+			w.sem.Release(grp)
+		}
+	}
+
+	return settle(han, rel), nil
+}
+
+// settle returns a function calling the given release function once the given
+// worker handler settled. Executions abandoned by the wrapped proxy handler may
+// keep running in the background, in which case their acquired slots must not
+// be freed before they finally returned. Otherwise the concurrency limits could
+// be exceeded by abandoned executions.
+func settle(han handler.Interface, rel func()) func() {
+	return func() {
+		var pen <-chan struct{}
+		for _, x := range handler.Stack(han) {
+			p, i := x.(handler.Pending)
+			if i {
+				pen = p.Pending()
+				break
+			}
+		}
+
+		if pen == nil {
+			rel()
+			return
+		}
+
+		select {
+		case <-pen:
+			rel()
+		default:
+			go func() {
+				<-pen
+				rel()
+			}()
+		}
+	}
+}
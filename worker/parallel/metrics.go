@@ -0,0 +1,31 @@
+package parallel
+
+import (
+	"maps"
+	"time"
+
+	"github.com/0xSplits/workit/handler/metrics"
+	"github.com/xh3b4sd/tracer"
+)
+
+// insCoo records the time that the given worker handler actually slept between
+// executions, which may be shorter than its cooler if it got triggered. The
+// static labels of the given worker handler are recorded alongside.
+func (w *Worker) insCoo(rec *record, dur time.Duration) {
+	lab := map[string]string{
+		"handler": rec.nam,
+	}
+
+	{
+		maps.Copy(lab, rec.lab)
+	}
+
+	err := w.met.Histogram(metrics.MetricCooler, dur.Seconds(), lab)
+	if err != nil {
+		w.log.Log(
+			"level", "error",
+			"message", "worker instrumentation failed",
+			"stack", tracer.Json(err),
+		)
+	}
+}
@@ -0,0 +1,136 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Worker_Parallel_Daemon_observer verifies that the *parallel.Worker
+// notifies the configured observer about the lifecycle of all worker handler
+// executions.
+func Test_Worker_Parallel_Daemon_observer(t *testing.T) {
+	var obs *testObserver
+	{
+		obs = &testObserver{}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&activeHandler{act: false, nam: "foo"},
+				&testHandler{coo: time.Hour, nam: "bar"},
+				&testHandler{coo: time.Hour, err: errors.New("test error"), nam: "baz"},
+			},
+			Log: logger.Fake(),
+			Obs: obs,
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	for len(obs.events()) < 8 {
+		time.Sleep(time.Millisecond)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	var eve []string
+	{
+		eve = obs.events()
+		slices.Sort(eve)
+	}
+
+	{
+		exp := []string{
+			"cooler bar",
+			"cooler baz",
+			"cooler foo",
+			"error baz",
+			"skip foo",
+			"start bar",
+			"start baz",
+			"success bar",
+		}
+		if dif := cmp.Diff(exp, eve); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+}
+
+type testObserver struct {
+	eve []string
+	mut sync.Mutex
+}
+
+func (o *testObserver) add(eve string) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	o.eve = append(o.eve, eve)
+}
+
+func (o *testObserver) events() []string {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	return slices.Clone(o.eve)
+}
+
+func (o *testObserver) OnStart(nam string) {
+	o.add("start " + nam)
+}
+
+func (o *testObserver) OnSuccess(nam string, _ time.Duration) {
+	o.add("success " + nam)
+}
+
+func (o *testObserver) OnError(nam string, _ time.Duration, _ error) {
+	o.add("error " + nam)
+}
+
+func (o *testObserver) OnSkip(nam string) {
+	o.add("skip " + nam)
+}
+
+func (o *testObserver) OnCoolerStart(nam string, _ time.Duration) {
+	o.add("cooler " + nam)
+}
+
+func (o *testObserver) OnGraphStart(nam string) {
+	o.add("graph start " + nam)
+}
+
+func (o *testObserver) OnGraphEnd(nam string, _ time.Duration, err error) {
+	if err != nil {
+		o.add("graph failure " + nam)
+	} else {
+		o.add("graph success " + nam)
+	}
+}
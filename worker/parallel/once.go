@@ -0,0 +1,62 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// RunOnce executes every active worker handler exactly once, all of them
+// concurrently, and blocks until all executions finished. Other than
+// Worker.Daemon, RunOnce neither applies cooler durations, strict schedules nor
+// initial delays, which makes it suitable for e.g. CronJobs, CLI tools and end
+// to end tests. The given context is provided to all worker handlers
+// implementing handler.EnsureContext. The errors of all failed worker handlers
+// are returned, including errors filtered by the configured metrics registry,
+// so that the caller decides which errors to act on.
+func (w *Worker) RunOnce(ctx context.Context) error {
+	// Every worker handler writes its own error only, so that the errors can be
+	// reported in the order of the worker handlers without further
+	// synchronization.
+
+	var res []error
+	{
+		res = make([]error, len(w.han))
+	}
+
+	var grp sync.WaitGroup
+	for i, h := range w.han {
+		grp.Add(1)
+		go func() {
+			defer grp.Done()
+			res[i] = w.run(ctx, h, w.rec[i])
+		}()
+	}
+
+	{
+		grp.Wait()
+	}
+
+	// Return the error of a single failed worker handler as is, and join the
+	// errors of multiple failed worker handlers, so that every failed worker
+	// handler is reported.
+
+	var err []error
+	for _, x := range res {
+		if x != nil {
+			err = append(err, x)
+		}
+	}
+
+	if len(err) == 1 {
+		return tracer.Mask(err[0])
+	}
+
+	if len(err) > 1 {
+		return tracer.Mask(errors.Join(err...))
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package parallel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/semaphore"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Worker_Parallel_RunOnce verifies that the *parallel.Worker executes
+// every active worker handler exactly once, and that the errors of all failed
+// worker handlers are reported.
+func Test_Worker_Parallel_RunOnce(t *testing.T) {
+	var one error
+	var two error
+	{
+		one = errors.New("first error")
+		two = errors.New("second error")
+	}
+
+	testCases := []struct {
+		fil func(error) bool
+		han []handler.Cooler
+		exe int
+		err []error
+	}{
+		// Case 000, all worker handlers succeed
+		{
+			han: []handler.Cooler{
+				&activeHandler{act: true, nam: "foo"},
+				&activeHandler{act: true, nam: "bar"},
+			},
+			exe: 2,
+			err: nil,
+		},
+		// Case 001, inactive worker handlers are not executed
+		{
+			han: []handler.Cooler{
+				&activeHandler{act: true, nam: "foo"},
+				&activeHandler{act: false, nam: "bar"},
+			},
+			exe: 1,
+			err: nil,
+		},
+		// Case 002, a single failed worker handler
+		{
+			han: []handler.Cooler{
+				&activeHandler{act: true, nam: "foo"},
+				&testHandler{err: one, nam: "bar"},
+			},
+			exe: 1,
+			err: []error{one},
+		},
+		// Case 003, multiple failed worker handlers
+		{
+			han: []handler.Cooler{
+				&testHandler{err: one, nam: "foo"},
+				&activeHandler{act: true, nam: "bar"},
+				&testHandler{err: two, nam: "baz"},
+			},
+			exe: 1,
+			err: []error{one, two},
+		},
+		// Case 004, function adapters are executed like any other worker handler
+		{
+			han: []handler.Cooler{
+				handler.NewFunc(handler.FuncConfig{Fun: func() error { return one }, Nam: "foo"}),
+				handler.NewFunc(handler.FuncConfig{Act: func() bool { return false }, Fun: func() error { return two }, Nam: "bar"}),
+				&activeHandler{act: true, nam: "baz"},
+			},
+			exe: 1,
+			err: []error{one},
+		},
+		// Case 005, filtered errors are returned like any other error
+		{
+			fil: func(err error) bool { return errors.Is(err, two) },
+			han: []handler.Cooler{
+				&testHandler{err: one, nam: "foo"},
+				&testHandler{err: two, nam: "bar"},
+			},
+			exe: 0,
+			err: []error{one, two},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sig chan int
+			{
+				sig = make(chan int, len(tc.han))
+			}
+
+			for _, x := range tc.han {
+				a, o := x.(*activeHandler)
+				if o {
+					a.sig = sig
+				}
+			}
+
+			var wor *Worker
+			{
+				wor = New(Config{
+					Han: tc.han,
+					Log: logger.Fake(),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Fil: tc.fil,
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+				})
+			}
+
+			var err error
+			{
+				err = wor.RunOnce(context.Background())
+			}
+
+			if len(sig) != tc.exe {
+				t.Fatal("expected", tc.exe, "got", len(sig))
+			}
+
+			if len(tc.err) == 0 && err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+
+			for _, x := range tc.err {
+				if !errors.Is(err, x) {
+					t.Fatal("expected", x, "got", err)
+				}
+			}
+		})
+	}
+}
+
+// Test_Worker_Parallel_RunOnce_acquire verifies that the *parallel.Worker
+// returns the error of cancelled concurrency limits, instead of silently
+// skipping the worker handler waiting for its resource group.
+func Test_Worker_Parallel_RunOnce_acquire(t *testing.T) {
+	var gro *limitCounter
+	{
+		gro = &limitCounter{}
+	}
+
+	var sem *semaphore.Semaphore
+	{
+		sem = semaphore.New(semaphore.Config{
+			Lim: map[string]int{
+				"rpc": 1,
+			},
+		})
+	}
+
+	// Occupy the only slot of the resource group, so that the worker handler
+	// waits until the given context got cancelled.
+
+	{
+		err := sem.Acquire(context.Background(), "rpc")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: []handler.Cooler{
+				&limitHandler{cou: &limitCounter{}, gro: gro, grp: "rpc", nam: "foo"},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Sem: sem,
+		})
+	}
+
+	var err error
+	{
+		ctx, can := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err = wor.RunOnce(ctx)
+		can()
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("expected", context.DeadlineExceeded, "got", err)
+	}
+
+	if gro.total() != 0 {
+		t.Fatal("expected", 0, "got", gro.total())
+	}
+}
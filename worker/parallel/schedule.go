@@ -0,0 +1,134 @@
+package parallel
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/schedule"
+)
+
+// schedule executes the given worker handler on its strict schedule. Other
+// than the cooler based execution, the activation times of a strict schedule
+// are independent of the execution time of the worker handler. Activation times
+// that passed while the worker handler was still executing are dealt with
+// according to the missed-run policy of the given schedule.
+func (w *Worker) schedule(ctx context.Context, han handler.Interface, rec *record, sch schedule.Interface) {
+	var nxt time.Time
+	{
+		nxt = sch.Next(time.Now())
+	}
+
+	for {
+		var coo time.Duration
+		{
+			coo = time.Until(nxt)
+		}
+
+		{
+			w.hea.Cooling(rec.nam, coo)
+			w.state(rec, func(r *record) { r.coo = coo; r.nxt = nxt })
+			w.obs.OnCoolerStart(rec.nam, coo)
+		}
+
+		w.log.Log(
+			"level", "debug",
+			"message", "scheduled worker handler",
+			"handler", rec.nam,
+			"next", nxt.String(),
+		)
+
+		// Triggered executions do not affect the strict schedule, which is why we
+		// keep waiting for the same activation time afterwards.
+
+		if w.wait(ctx, han, rec, nxt) {
+			return
+		}
+
+		{
+			w.cycle(ctx, han, rec)
+		}
+
+		{
+			nxt = missed(sch, nxt, time.Now())
+		}
+	}
+}
+
+// missed returns the next activation time following the given last activation
+// time, according to the missed-run policy of the given schedule. Any
+// activation time not after the given current time has been missed.
+func missed(sch schedule.Interface, las time.Time, now time.Time) time.Time {
+	var nxt time.Time
+	{
+		nxt = sch.Next(las)
+	}
+
+	if nxt.IsZero() || nxt.After(now) {
+		return nxt
+	}
+
+	switch sch.Missed() {
+	case schedule.MissedAll:
+		// Return the first missed activation time, so that every missed activation
+		// time is executed one after another.
+		return nxt
+	case schedule.MissedOnce:
+		// Return the last missed activation time, so that only a single execution
+		// catches up on all missed activation times.
+		for {
+			n := sch.Next(nxt)
+			if n.IsZero() || n.After(now) {
+				return nxt
+			}
+
+			nxt = n
+		}
+	}
+
+	return sch.Next(now)
+}
+
+// wait blocks until the given activation time is reached, and executes the
+// given worker handler whenever it gets triggered in the meantime. Blocking
+// forever is valid if the given activation time is zero, e.g. because the cron
+// expression can never match. wait returns true if the worker engine is about
+// to stop.
+func (w *Worker) wait(ctx context.Context, han handler.Interface, rec *record, nxt time.Time) bool {
+	var tic <-chan time.Time
+	if !nxt.IsZero() {
+		tim := time.NewTimer(time.Until(nxt))
+		defer tim.Stop()
+		tic = tim.C
+	}
+
+	for {
+		var wak bool
+
+		select {
+		case <-w.stp:
+			return true
+		case <-tic:
+		case <-rec.wak:
+			wak = true
+		}
+
+		// Do not execute the worker handler if we were asked to stop while the
+		// activation time was reached, or while a trigger was received at the
+		// same time.
+
+		select {
+		case <-w.stp:
+			return true
+		default:
+		}
+
+		if !wak {
+			return false
+		}
+
+		{
+			w.cycle(ctx, han, rec)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package parallel
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/workit/schedule"
+)
+
+func Test_Worker_Parallel_missed(t *testing.T) {
+	var bas time.Time
+	{
+		bas = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		mis string
+		las time.Time
+		now time.Time
+		nxt time.Time
+	}{
+		// Case 000, nothing missed
+		{
+			mis: schedule.MissedSkip,
+			las: bas,
+			now: bas.Add(30 * time.Second),
+			nxt: bas.Add(time.Minute),
+		},
+		// Case 001, nothing missed
+		{
+			mis: schedule.MissedAll,
+			las: bas,
+			now: bas.Add(30 * time.Second),
+			nxt: bas.Add(time.Minute),
+		},
+		// Case 002, skip all missed activations
+		{
+			mis: schedule.MissedSkip,
+			las: bas,
+			now: bas.Add(3*time.Minute + 30*time.Second),
+			nxt: bas.Add(4 * time.Minute),
+		},
+		// Case 003, execute the last missed activation once
+		{
+			mis: schedule.MissedOnce,
+			las: bas,
+			now: bas.Add(3*time.Minute + 30*time.Second),
+			nxt: bas.Add(3 * time.Minute),
+		},
+		// Case 004, execute all missed activations one by one
+		{
+			mis: schedule.MissedAll,
+			las: bas,
+			now: bas.Add(3*time.Minute + 30*time.Second),
+			nxt: bas.Add(time.Minute),
+		},
+		// Case 005, activation time equal to now has been missed
+		{
+			mis: schedule.MissedSkip,
+			las: bas,
+			now: bas.Add(time.Minute),
+			nxt: bas.Add(2 * time.Minute),
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sch schedule.Interface
+			{
+				sch = schedule.New(schedule.Config{
+					Dur: time.Minute,
+					Mis: tc.mis,
+				})
+			}
+
+			nxt := missed(sch, tc.las, tc.now)
+			if !nxt.Equal(tc.nxt) {
+				t.Fatalf("expected %s got %s", tc.nxt, nxt)
+			}
+		})
+	}
+}
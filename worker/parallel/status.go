@@ -0,0 +1,129 @@
+package parallel
+
+import (
+	"time"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/tracer"
+)
+
+// record is the runtime state of a single worker handler managed by this
+// worker engine. All fields are guarded by the mutex of the worker engine,
+// except for the immutable name and the wake channel.
+type record struct {
+	act bool
+	coo time.Duration
+	dur time.Duration
+	err error
+	lab map[string]string
+	nam string
+	nxt time.Time
+	pau bool
+	sta string
+	wak chan struct{}
+}
+
+// Status returns the runtime snapshot of all worker handlers managed by this
+// worker engine, in the order that they were configured.
+func (w *Worker) Status() []status.Status {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	var lis []status.Status
+	for i, x := range w.rec {
+		lis = append(lis, status.Status{
+			Active:   x.act,
+			Cooler:   x.coo,
+			Duration: x.dur,
+			Engine:   "parallel",
+			Error:    x.err,
+			Index:    i,
+			Name:     x.nam,
+			Paused:   x.pau,
+			Stage:    0,
+			State:    x.sta,
+		})
+	}
+
+	return lis
+}
+
+// Pause disables the worker handler of the given name until it is resumed
+// again. In-flight executions are not affected.
+func (w *Worker) Pause(nam string) error {
+	err := w.update(nam, func(r *record) { r.pau = true })
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker paused handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// Resume enables the paused worker handler of the given name again, starting
+// with its next cycle.
+func (w *Worker) Resume(nam string) error {
+	err := w.update(nam, func(r *record) { r.pau = false })
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker resumed handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// Next returns the next activation time of the worker handler with the given
+// name, if that worker handler is executed on a strict schedule. The zero time
+// is returned otherwise.
+func (w *Worker) Next(nam string) time.Time {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	for _, x := range w.rec {
+		if x.nam == nam {
+			return x.nxt
+		}
+	}
+
+	return time.Time{}
+}
+
+// update applies the given function to the worker handler of the given name,
+// and returns status.NotFoundError if no such worker handler exists.
+func (w *Worker) update(nam string, fnc func(r *record)) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	var fou bool
+	for _, x := range w.rec {
+		if x.nam == nam {
+			fnc(x)
+			fou = true
+		}
+	}
+
+	if !fou {
+		return tracer.Mask(status.NotFoundError, tracer.Context{Key: "handler", Value: nam})
+	}
+
+	return nil
+}
+
+// state applies the given function to the given record while holding the
+// mutex of this worker engine.
+func (w *Worker) state(rec *record, fnc func(r *record)) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	fnc(rec)
+}
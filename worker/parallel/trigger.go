@@ -0,0 +1,49 @@
+package parallel
+
+import "github.com/xh3b4sd/tracer"
+
+// Trigger interrupts the cooler of the worker handler of the given name, so
+// that it gets executed right away. Worker handlers being executed while
+// triggered are executed once more right after their current execution.
+// Multiple triggers received in the meantime are coalesced into a single
+// execution.
+func (w *Worker) Trigger(nam string) error {
+	err := w.update(nam, func(r *record) {
+		select {
+		case r.wak <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	w.log.Log(
+		"level", "debug",
+		"message", "worker triggered handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// listen triggers the worker handlers of all names received via the event
+// channel, until the event channel got closed, or until the worker engine is
+// about to stop.
+func (w *Worker) listen() {
+	for {
+		select {
+		case <-w.stp:
+			return
+		case nam, ok := <-w.eve:
+			if !ok {
+				return
+			}
+
+			err := w.Trigger(nam)
+			if err != nil {
+				w.error(tracer.Mask(err))
+			}
+		}
+	}
+}
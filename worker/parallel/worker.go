@@ -2,49 +2,187 @@ package parallel
 
 import (
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/executor"
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/handler/metrics"
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/leader"
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/observer"
 	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/semaphore"
+	"github.com/0xSplits/workit/status"
 	"github.com/xh3b4sd/logger"
 	"github.com/xh3b4sd/tracer"
 )
 
 type Config struct {
+	// Del is the optional initial delay applied before the first execution of
+	// every worker handler, unless the underlying worker handler implements the
+	// handler.Jitter interface. No initial delay is applied by default.
+	Del time.Duration
+
+	// Eve is the optional channel of worker handler names to trigger, e.g. fed
+	// by webhook or message queue consumers. Every name received interrupts the
+	// cooler of the respective worker handlers like Worker.Trigger does, so that
+	// they get executed right away. The channel is consumed for as long as
+	// Worker.Daemon is running.
+	Eve <-chan string
+
+	// Gra is the optional grace period granted to in-flight worker handler
+	// executions once Worker.Daemon got stopped. The execution context provided
+	// to worker handlers implementing handler.EnsureContext is cancelled after
+	// this grace period expired. Defaults to 20 seconds, which fits into the
+	// default termination grace period of Kubernetes pods.
+	Gra time.Duration
+
 	// Han is the list of worker handlers implementing the actual business logic
 	// as distinct execution pipelines. The worker handlers configured here may be
 	// wrapped in administrative handler implementations to e.g. instrument
 	// handler execution latency and handler error rates. All worker handlers
 	// provided here will be executed concurrently within their own isolated
-	// failure domain.
+	// failure domain. Worker handlers implementing handler.Schedule are executed
+	// on their strict schedule, instead of sleeping for their cooler.
 	Han []handler.Cooler
 
+	// Hea is the optional health tracker recording the execution state of all
+	// worker handlers, so that their health can be exposed via HTTP.
+	Hea *health.Health
+
+	// Jit is the optional fraction of random jitter applied to the initial delay
+	// and to every cooler of all worker handlers, e.g. 0.1 for +-10%, unless the
+	// underlying worker handler implements the handler.Jitter interface. Must be
+	// within [0, 1). No jitter is applied by default.
+	Jit float64
+
+	// Loc is the optional locker used for leader election across multiple
+	// replicas. If provided, every worker handler is only executed while this
+	// worker engine holds the lease of the respective worker handler, keyed by
+	// handler name. Worker handlers implementing handler.Locker may provide their
+	// own locker instead. No leader election is applied by default.
+	Loc locker.Interface
+
 	// Log is a standard logger interface to forward structured log messages to
 	// any output interface e.g. stdout.
 	Log logger.Interface
 
+	// Max is the optional maximum number of worker handlers executed
+	// concurrently. Worker handlers exceeding this limit wait for a running
+	// worker handler to finish before being executed. No limit is applied by
+	// default.
+	Max int
+
+	// Mid is the optional list of middlewares wrapping every worker handler of
+	// this worker engine, in the given order. These middlewares are applied
+	// inside of the middlewares configured on Reg, so that e.g. registry wide
+	// middlewares observe every execution first.
+	Mid []handler.Middleware
+
+	// Obs is the optional observer notified about the lifecycle of all worker
+	// handler executions, e.g. to implement alerting or audit logs. All hooks are
+	// invoked synchronously. No observer is notified by default.
+	Obs observer.Interface
+
+	// Pan is the optional flag to disable the recovery of panicking worker
+	// handlers. By default, any panic is converted into an error that is logged
+	// and instrumented, so that the worker engine keeps running. Setting Pan to
+	// true crashes the process instead.
+	Pan bool
+
 	// Reg is the metrics interface used to wrap the internally managed handlers
 	// for instrumentation purposes. The metrics handlers created by this registry
 	// will record all worker handler execution metrics.
 	Reg *registry.Registry
+
+	// Ren is the optional interval at which leases are renewed while the work
+	// guarded by them is still executing. Executions are cancelled once their
+	// lease cannot be renewed anymore. The interval must be shorter than the
+	// lease duration of the configured lockers. Defaults to 10 seconds.
+	Ren time.Duration
+
+	// Sem is the optional semaphore bounding the number of concurrent executions
+	// of all worker handlers sharing the same resource group. Sem must be
+	// provided if any worker handler implements handler.Group, and must contain
+	// all resource groups of those worker handlers.
+	Sem *semaphore.Semaphore
+
+	// Spr is the optional window across which the first executions of all
+	// worker handlers are spread evenly, in the order of Han. The stagger of
+	// every worker handler is added to its initial delay, so that e.g. 4 worker
+	// handlers with a window of 1 minute start 15 seconds apart. No stagger is
+	// applied by default.
+	Spr time.Duration
+
+	// Tim is the optional default timeout applied to all worker handler
+	// executions, unless the underlying worker handler implements the
+	// handler.Timeout interface. Executions exceeding their timeout are abandoned
+	// and recorded as timeout failures. No timeout is applied by default.
+	Tim time.Duration
 }
 
 type Worker struct {
+	del time.Duration
+	don chan struct{}
+	exe *executor.Executor
+	eve <-chan string
+	gra time.Duration
 	han []handler.Interface
+	hea *health.Health
+	jit float64
+	lea *leader.Leader
+	lim chan struct{}
+	loc locker.Interface
 	log logger.Interface
-	reg *registry.Registry
+	met otelreg.Interface
+	mut sync.Mutex
+	obs observer.Interface
+	onc sync.Once
 	rdy chan struct{}
+	rec []*record
+	reg *registry.Registry
+	sem *semaphore.Semaphore
+	spr time.Duration
+	stp chan struct{}
 }
 
 func New(c Config) *Worker {
+	if c.Del < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Del must not be negative", c)))
+	}
+	if c.Gra == 0 {
+		c.Gra = 20 * time.Second
+	}
 	if len(c.Han) == 0 {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not be empty", c)))
 	}
+	if c.Hea == nil {
+		c.Hea = health.New(health.Config{})
+	}
+	if c.Jit < 0 || c.Jit >= 1 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Jit must be within [0, 1)", c)))
+	}
 	if c.Log == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
 	}
+	if c.Max < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Max must not be negative", c)))
+	}
+	if c.Obs == nil {
+		c.Obs = observer.Fake{}
+	}
 	if c.Reg == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
 	}
+	if c.Spr < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Spr must not be negative", c)))
+	}
 
 	// Verify early on that no handler leaf is ever nil.
 
@@ -60,7 +198,139 @@ func New(c Config) *Worker {
 
 	var han []handler.Interface
 	for _, x := range c.Han {
-		han = append(han, c.Reg.New(x))
+		han = append(han, c.Reg.New(x, c.Mid...))
+	}
+
+	var key []string
+	for _, x := range han {
+		key = append(key, handler.Name(x.Unwrap()))
+	}
+
+	// Verify early on that all handler names are unique, because handler names
+	// identify worker handlers for instrumentation, health reporting and leader
+	// election.
+
+	dup := handler.Duplicates(key)
+	if len(dup) != 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not contain duplicate handler names, found %s", c, strings.Join(dup, ", "))))
+	}
+
+	// Verify early on that the resource groups of all worker handlers exist.
+
+	for i, x := range han {
+		grp := x.Group()
+		if grp == "" {
+			continue
+		}
+
+		if c.Sem == nil || !c.Sem.Exists(grp) {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Sem must contain resource group %s of handler %s", c, grp, key[i])))
+		}
+	}
+
+	// Verify early on that the jitter of all worker handlers is valid, just like
+	// the jitter of this worker engine. Note that negative fractions and negative
+	// initial delays are valid, because they disable jitter and initial delays
+	// for the respective worker handler.
+
+	for i, x := range han {
+		jit := x.Jitter()
+		if jit >= 1 || math.IsNaN(jit) {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not contain jitter of 1 or more, found %v for handler %s", c, jit, key[i])))
+		}
+	}
+
+	for _, x := range key {
+		c.Hea.Register(x)
+	}
+
+	var exe *executor.Executor
+	{
+		exe = executor.New(executor.Config{
+			Hea: c.Hea,
+			Pan: c.Pan,
+			Reg: c.Reg,
+			Tim: c.Tim,
+		})
+	}
+
+	var lea *leader.Leader
+	{
+		lea = leader.New(leader.Config{
+			Key: key,
+			Log: c.Log,
+			Reg: c.Reg,
+			Ren: c.Ren,
+		})
+	}
+
+	// Collect the static labels of all worker handlers, so that their cooler
+	// metrics can be queried by e.g. team, just like their execution metrics.
+	// Note that the static labels got already verified by the metrics registry.
+
+	var lab []map[string]string
+	for _, x := range han {
+		var l map[string]string
+		if y, i := x.Unwrap().(handler.Labeled); i {
+			l = y.Labels()
+		}
+
+		lab = append(lab, l)
+	}
+
+	// Whitelist the cooler metrics of all worker handlers, so that the actual
+	// sleep between executions can be instrumented, including jitter, backoff
+	// and triggered executions.
+
+	var coo map[string][]string
+	{
+		coo = map[string][]string{
+			"handler": key,
+		}
+	}
+
+	for _, x := range lab {
+		for k, v := range x {
+			if !slices.Contains(coo[k], v) {
+				coo[k] = append(coo[k], v)
+			}
+		}
+	}
+
+	var met otelreg.Interface
+	{
+		met = c.Reg.Metrics(nil, nil, map[string]registry.Metric{
+			metrics.MetricCooler: {
+				Buc: []float64{
+					1,
+					5,
+					10,
+					30,
+					60,
+					300,
+					900,
+					3600,
+				},
+				Des: "the time that worker handlers slept between executions",
+				Lab: coo,
+			},
+		})
+	}
+
+	var rec []*record
+	for i, x := range key {
+		rec = append(rec, &record{
+			act: true,
+			lab: lab[i],
+			nam: x,
+			sta: status.StateIdle,
+			wak: make(chan struct{}, 1),
+		})
+	}
+
+	var lim chan struct{}
+	if c.Max > 0 {
+		lim = make(chan struct{}, c.Max)
 	}
 
 	var rdy chan struct{}
@@ -69,9 +339,25 @@ func New(c Config) *Worker {
 	}
 
 	return &Worker{
+		del: c.Del,
+		don: make(chan struct{}),
+		exe: exe,
+		eve: c.Eve,
+		gra: c.Gra,
 		han: han,
+		hea: c.Hea,
+		jit: c.Jit,
+		lea: lea,
+		lim: lim,
+		loc: c.Loc,
 		log: c.Log,
-		reg: c.Reg,
+		met: met,
+		obs: c.Obs,
 		rdy: rdy,
+		rec: rec,
+		reg: c.Reg,
+		sem: c.Sem,
+		spr: c.Spr,
+		stp: make(chan struct{}),
 	}
 }
@@ -1,14 +1,24 @@
 package sequence
 
 import (
+	"context"
+	"time"
+
 	"github.com/xh3b4sd/choreo/ticker"
+	"github.com/xh3b4sd/tracer"
 )
 
-func (w *Worker) Daemon() {
+// Daemon executes the injected directed acyclic graph continuously and blocks
+// until the given context is cancelled, or until Worker.Stop is called. Once
+// stopped, no new graph executions are scheduled anymore, and Daemon waits for
+// any in-flight graph execution to finish within the configured grace period,
+// before returning.
+func (w *Worker) Daemon(ctx context.Context) {
 	// Explicitly disable Worker.Daemon if no cooler duration was provided. This
 	// turns Worker.Daemon into a noop without blocking and side effects.
 
 	if _, typ := w.tic.(ticker.Fake); typ {
+		close(w.don)
 		return
 	}
 
@@ -18,19 +28,131 @@ func (w *Worker) Daemon() {
 		"pipelines", "1",
 	)
 
-	// Run Worker.Ensure once initially and rely on the underlying ticker
-	// implementation to further trigger scheduled execution.
+	// Derive the execution context for all worker handlers from the given
+	// context. Note that we detach the cancellation of the given context, so that
+	// an in-flight graph execution may finish gracefully, even if the given
+	// context got cancelled. The execution context is only cancelled once the
+	// in-flight graph execution finished, or once the grace period expired.
+
+	var exe context.Context
+	var can context.CancelFunc
+	{
+		exe, can = context.WithCancel(context.WithoutCancel(ctx))
+	}
 
+	var don chan struct{}
 	{
-		w.ensure()
+		don = make(chan struct{})
 	}
 
-	// Execute Worker.Ensure based on the internally managed ticker
-	// implementation. Note that the delivered ticks are synchronized with the
-	// actual execution of Worker.Ensure, so that external calls reset the
-	// effective wait duration.
+	// Schedule the first graph execution right away.
+
+	{
+		w.cooling(0)
+	}
+
+	go func() {
+		defer close(don)
+
+		// Run Worker.Ensure once initially and rely on the underlying ticker
+		// implementation to further trigger scheduled execution.
+
+		{
+			w.ensure(exe)
+		}
+
+		// Execute Worker.Ensure based on the internally managed ticker
+		// implementation, or whenever Worker.Trigger is called. Note that the
+		// delivered ticks are synchronized with the actual execution of
+		// Worker.Ensure, so that external calls reset the effective wait duration.
 
-	for range w.tic.Ticks() {
-		w.ensure()
+		for {
+			var sta time.Time
+			{
+				sta = time.Now()
+			}
+
+			select {
+			case <-w.stp:
+				return
+			case <-w.tic.Ticks():
+			case <-w.wak:
+			}
+
+			{
+				w.insCoo(time.Since(sta))
+			}
+
+			// Do not schedule another graph execution if we were asked to stop while
+			// a tick was delivered at the same time.
+
+			select {
+			case <-w.stp:
+				return
+			default:
+			}
+
+			{
+				w.ensure(exe)
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case <-w.stp:
+	}
+
+	{
+		w.stop()
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker is stopping tasks",
+		"grace", w.gra.String(),
+	)
+
+	// Wait for the in-flight graph execution to finish. If the grace period
+	// expires before the graph execution returned, then we cancel the execution
+	// context and return anyway, so that Worker.Daemon never blocks the shutdown
+	// of the calling process indefinitely.
+
+	var tim *time.Timer
+	{
+		tim = time.NewTimer(w.gra)
+	}
+
+	select {
+	case <-don:
+		tim.Stop()
+	case <-tim.C:
+		w.log.Log(
+			"level", "warning",
+			"message", "worker grace period expired",
+			"grace", w.gra.String(),
+		)
+	}
+
+	// Cancel the execution context before giving up any lease, so that neither
+	// abandoned executions nor their lease renewals continue once the leases got
+	// released. Releasing the leases waits for all lease renewals to stop.
+
+	{
+		can()
+	}
+
+	// Give up all leases held by this worker engine, so that other replicas can
+	// take over right away.
+
+	{
+		err := w.lea.Release(context.WithoutCancel(ctx))
+		if err != nil {
+			w.error(tracer.Mask(err))
+		}
+	}
+
+	{
+		close(w.don)
 	}
 }
@@ -3,6 +3,7 @@
 package sequence
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"sync"
@@ -56,7 +57,7 @@ func Test_Worker_Sequence_Integration(t *testing.T) {
 	// Run the daemon and record the first two ticks.
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	{
@@ -2,20 +2,29 @@ package sequence
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/board"
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/leader"
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/locker/memory"
 	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/semaphore"
+	"github.com/0xSplits/workit/status"
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
@@ -56,7 +65,7 @@ func Test_Worker_Sequence_Daemon_active(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	var act []int
@@ -114,7 +123,7 @@ func Test_Worker_Sequence_Daemon_error(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	var act []int
@@ -134,7 +143,7 @@ func Test_Worker_Sequence_Daemon_error(t *testing.T) {
 	}
 
 	{
-		exp := `"level":"error", "message":"worker execution failed", "stack":{"context":[{"key":"handler","value":"sequence"}],"description":"test error",`
+		exp := `"level":"error", "message":"worker execution failed", "stack":{"context":[{"key":"handler","value":"error5"}],"description":"test error",`
 		if !strings.Contains(buf.String(), exp) {
 			t.Fatal("expected", true, "got", false)
 		}
@@ -174,7 +183,7 @@ func Test_Worker_Sequence_Daemon_order(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	var act []int
@@ -245,7 +254,7 @@ func Test_Worker_Sequence_Daemon_metrics(t *testing.T) {
 		res = tesRes(url)
 	}
 
-	pat := `worker_handler_execution_total\{env="testing",handler="sequence",otel_scope_name="workit\.testing\.splits\.org",otel_scope_schema_url="",otel_scope_version="[^"]*",success="true"\} 5`
+	pat := `worker_handler_execution_total\{env="testing",handler="order[3-7]",otel_scope_name="workit\.testing\.splits\.org",otel_scope_schema_url="",otel_scope_version="[^"]*",success="true"\} 1`
 	rgx := regexp.MustCompile(pat)
 
 	if rgx.MatchString(res) {
@@ -253,7 +262,7 @@ func Test_Worker_Sequence_Daemon_metrics(t *testing.T) {
 	}
 
 	{
-		go wor.Daemon()
+		go wor.Daemon(context.Background())
 	}
 
 	var act []int
@@ -274,41 +283,725 @@ func Test_Worker_Sequence_Daemon_metrics(t *testing.T) {
 		res = tesRes(url)
 	}
 
+	if len(rgx.FindAllString(res, -1)) != 5 {
+		t.Fatal("expected", 5, "got", len(rgx.FindAllString(res, -1)))
+	}
+}
+
+// Test_Worker_Sequence_Daemon_cooler verifies that the *sequence.Worker records
+// the actual sleep between graph executions.
+func Test_Worker_Sequence_Daemon_cooler(t *testing.T) {
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var sig chan int
+	{
+		sig = make(chan int, 10)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Coo: time.Hour,
+			Han: [][]handler.Ensure{
+				{&orderHandler{sig, 1, false}},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	{
+		go wor.Daemon(context.Background())
+	}
+
+	// Trigger the graph while it is cooling down, so that its cooler got
+	// recorded once the second graph execution is in-flight.
+
+	{
+		<-sig
+	}
+
+	{
+		err := wor.Trigger("order1")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		<-sig
+	}
+
+	pat := `worker_graph_cooler_duration_seconds_count\{env="testing",[^}]*pipeline="sequence"\} 1`
+	rgx := regexp.MustCompile(pat)
+
+	var res string
+	{
+		res = tesRes(url)
+	}
+
 	if !rgx.MatchString(res) {
-		t.Fatal("expected", true, "got", false)
+		t.Fatalf("expected %s to match\n%s", pat, res)
+	}
+
+	{
+		err := wor.Stop(context.Background())
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Ensure_filter verifies that the *sequence.Worker does
+// not log filtered errors. Note that this test also covers the case of
+// Config.Coo to be empty, causing the worker engine to allocate a fake ticker
+// internally, which can be done to only use Worker.Ensure without
+// Worker.Daemon.
+func Test_Worker_Sequence_Ensure_filter(t *testing.T) {
+	var buf syncBuffer
+
+	var sig chan int
+	{
+		sig = make(chan int, 5)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: [][]handler.Ensure{
+				{&errorHandler{sig, 3, nil}},
+				{&errorHandler{sig, 4, nil}},
+				{&errorHandler{sig, 5, errors.New("test error")}},
+				{&errorHandler{sig, 6, nil}},
+				{&errorHandler{sig, 7, nil}},
+			},
+			Log: logger.New(logger.Config{
+				Filter: logger.NewLevelFilter("error"),
+				Writer: &buf,
+			}),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Fil: isErr,
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		wor.Daemon(context.Background()) // noop without cooler duration, would block otherwise
+	}
+
+	{
+		wor.ensure(context.Background()) // Ensure instead of Daemon
+	}
+
+	var act []int
+	for x := range sig {
+		act = append(act, x)
+	}
+
+	{
+		exp := []int{3, 4, 5}
+		if dif := cmp.Diff(exp, act); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+
+	{
+		exp := ""
+		if dif := cmp.Diff(exp, buf.String()); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Daemon_stop verifies that the *sequence.Worker waits for
+// in-flight executions when being stopped, and that the execution context is
+// only cancelled once the grace period expired.
+func Test_Worker_Sequence_Daemon_stop(t *testing.T) {
+	testCases := []struct {
+		blo bool
+		gra time.Duration
+		err error
+	}{
+		// Case 000, in-flight execution finishes within the grace period
+		{
+			blo: false,
+			gra: time.Second,
+			err: nil,
+		},
+		// Case 001, in-flight execution exceeds the grace period
+		{
+			blo: true,
+			gra: 10 * time.Millisecond,
+			err: context.Canceled,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sig chan struct{}
+			var rel chan struct{}
+			var out chan error
+			{
+				sig = make(chan struct{})
+				rel = make(chan struct{})
+				out = make(chan error, 1)
+			}
+
+			var wor *Worker
+			{
+				wor = New(Config{
+					Coo: time.Hour,
+					Gra: tc.gra,
+					Han: [][]handler.Ensure{
+						{&stopHandler{sig: sig, rel: rel, out: out, blo: tc.blo}},
+					},
+					Log: logger.Fake(),
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+				})
+			}
+
+			var ctx context.Context
+			var can context.CancelFunc
+			{
+				ctx, can = context.WithCancel(context.Background())
+			}
+
+			var don chan struct{}
+			{
+				don = make(chan struct{})
+			}
+
+			go func() {
+				wor.Daemon(ctx)
+				close(don)
+			}()
+
+			// Wait for the worker handler to be in-flight and cancel the daemon
+			// context while the worker handler is still executing. Only then we
+			// release the worker handler.
+
+			{
+				<-sig
+			}
+
+			{
+				can()
+				close(rel)
+			}
+
+			select {
+			case <-don:
+			case <-time.After(time.Second):
+				t.Fatal("test timeout")
+			}
+
+			{
+				err := wor.Stop(context.Background())
+				if err != nil {
+					t.Fatal("expected", nil, "got", err)
+				}
+			}
+
+			{
+				err := <-out
+				if !errors.Is(err, tc.err) {
+					t.Fatal("expected", tc.err, "got", err)
+				}
+			}
+		})
+	}
+}
+
+// Test_Worker_Sequence_Ensure_timeout verifies that the *sequence.Worker
+// abandons worker handlers exceeding their timeout, so that a hanging worker
+// handler cannot block the entire graph forever.
+func Test_Worker_Sequence_Ensure_timeout(t *testing.T) {
+	var sig chan int
+	{
+		sig = make(chan int, 1)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: [][]handler.Ensure{
+				{&orderHandler{sig, 3, false}, &blockHandler{}},
+				{&orderHandler{sig, 4, false}},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Tim: 10 * time.Millisecond,
+		})
+	}
+
+	var err error
+	{
+		err = wor.Ensure()
+	}
+
+	if !handler.IsTimeout(err) {
+		t.Fatal("expected", handler.TimeoutError, "got", err)
+	}
+
+	{
+		close(sig)
+	}
+
+	var act []int
+	for x := range sig {
+		act = append(act, x)
+	}
+
+	{
+		exp := []int{3}
+		if dif := cmp.Diff(exp, act); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Ensure_leader verifies that only a single replica of
+// the *sequence.Worker executes the graph, if leader election is enabled, and
+// that worker handlers may opt into leader election individually.
+func Test_Worker_Sequence_Ensure_leader(t *testing.T) {
+	var sto *memory.Store
+	{
+		sto = &memory.Store{}
+	}
+
+	var han []*leaderHandler
+	var wor []*Worker
+	for _, x := range []string{"one", "two"} {
+		h := &leaderHandler{}
+
+		w := New(Config{
+			Han: [][]handler.Ensure{{h}},
+			Loc: memory.New(memory.Config{Own: x, Sto: sto}),
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+
+		han = append(han, h)
+		wor = append(wor, w)
+	}
+
+	for range 3 {
+		for _, x := range wor {
+			err := x.Ensure()
+			if err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+		}
+	}
+
+	if han[0].cou != 3 {
+		t.Fatal("expected", 3, "got", han[0].cou)
+	}
+	if han[1].cou != 0 {
+		t.Fatal("expected", 0, "got", han[1].cou)
+	}
+
+	// Worker handlers may opt into leader election individually, even without
+	// any locker configured on the worker engine. Note that the lease key of
+	// worker handlers is their handler name.
+
+	var oth *memory.Memory
+	var loc *leaderHandler
+	{
+		sto = &memory.Store{}
+		oth = memory.New(memory.Config{Own: "two", Sto: sto})
+		loc = &leaderHandler{loc: memory.New(memory.Config{Own: "one", Sto: sto})}
+	}
+
+	var opt *Worker
+	{
+		opt = New(Config{
+			Han: [][]handler.Ensure{{loc}},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		_, err := oth.Acquire(context.Background(), "sequence")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := opt.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if loc.cou != 0 {
+		t.Fatal("expected", 0, "got", loc.cou)
+	}
+
+	{
+		err := oth.Release(context.Background(), "sequence")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := opt.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if loc.cou != 1 {
+		t.Fatal("expected", 1, "got", loc.cou)
+	}
+}
+
+// Test_Worker_Sequence_Ensure_lease verifies that the *sequence.Worker renews
+// the lease of the graph during the graph execution, and cancels the graph
+// execution once the lease got lost.
+func Test_Worker_Sequence_Ensure_lease(t *testing.T) {
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: [][]handler.Ensure{{handler.NewFunc(handler.FuncConfig{
+				Ctx: func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+				Nam: "block",
+			})}},
+			Loc: &lostLocker{},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Ren: time.Millisecond,
+		})
+	}
+
+	err := wor.Ensure()
+	if !leader.IsLeaseLost(err) {
+		t.Fatal("expected", leader.LeaseLostError, "got", err)
+	}
+}
+
+// Test_Worker_Sequence_Ensure_status verifies that the *sequence.Worker
+// exposes the runtime state of its worker handlers including their graph
+// position, and that paused worker handlers are skipped.
+func Test_Worker_Sequence_Ensure_status(t *testing.T) {
+	var wor *Worker
+	{
+		wor = New(Config{
+			Coo: time.Minute,
+			Han: [][]handler.Ensure{
+				{&statusHandler{act: true, nam: "foo"}},
+				{&statusHandler{act: true, err: errors.New("test error"), nam: "bar"}, &statusHandler{act: false, nam: "baz"}},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "test error", "got", nil)
+		}
+	}
+
+	var sta []status.Status
+	{
+		sta = wor.Status()
+	}
+
+	{
+		for i := range sta {
+			sta[i].Duration = 0
+		}
+		if sta[1].Error == nil || !strings.Contains(sta[1].Error.Error(), "test error") {
+			t.Fatal("expected", "test error", "got", sta[1].Error)
+		}
+		sta[1].Error = nil
+	}
+
+	exp := []status.Status{
+		{Active: true, Cooler: time.Minute, Engine: "sequence", Index: 0, Name: "foo", Stage: 0, State: status.StateCooling},
+		{Active: true, Cooler: time.Minute, Engine: "sequence", Index: 0, Name: "bar", Stage: 1, State: status.StateCooling},
+		{Active: false, Cooler: time.Minute, Engine: "sequence", Index: 1, Name: "baz", Stage: 1, State: status.StateDisabled},
+	}
+
+	if dif := cmp.Diff(exp, sta); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	// Paused worker handlers are skipped, so that the graph succeeds.
+
+	for _, x := range []string{"foo", "bar", "baz"} {
+		err := wor.Pause(x)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := wor.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	for _, x := range wor.Status() {
+		if !x.Paused || x.State != status.StateDisabled {
+			t.Fatal("expected", status.StateDisabled, "got", x.State)
+		}
+	}
+
+	{
+		err := wor.Trigger("unknown")
+		if !status.IsNotFound(err) {
+			t.Fatal("expected", status.NotFoundError, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Trigger_disabled verifies that the *sequence.Worker
+// rejects triggers if no cooler duration was configured, because
+// Worker.Daemon does not execute the graph in that case.
+func Test_Worker_Sequence_Trigger_disabled(t *testing.T) {
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: [][]handler.Ensure{
+				{&statusHandler{act: true, nam: "foo"}},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		err := wor.Trigger("foo")
+		if !status.IsDaemonDisabled(err) {
+			t.Fatal("expected", status.DaemonDisabledError, "got", err)
+		}
+	}
+
+	{
+		err := wor.Trigger("unknown")
+		if !status.IsNotFound(err) {
+			t.Fatal("expected", status.NotFoundError, "got", err)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Ensure_board verifies that the *sequence.Worker
+// provides a board to every graph execution, so that nodes can pass data to
+// their dependents, while every graph execution starts with a fresh board.
+func Test_Worker_Sequence_Ensure_board(t *testing.T) {
+	var inp board.Key[int]
+	var out board.Key[int]
+	{
+		inp = board.NewKey[int]("input")
+		out = board.NewKey[int]("output")
+	}
+
+	var wri = func(key board.Key[int], val int) func(context.Context) error {
+		return func(ctx context.Context) error {
+			return board.Set(ctx, key, val)
+		}
+	}
+
+	var sum = func(ctx context.Context) error {
+		var res int
+		for _, x := range []string{"a", "b", "input"} {
+			val, exi := board.Get(ctx, board.NewKey[int](x))
+			if !exi {
+				return fmt.Errorf("%s must not be empty", x)
+			}
+
+			{
+				res += val
+			}
+		}
+
+		return board.Set(ctx, out, res)
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nod: []Node{
+				{Nam: "a", Han: &boardHandler{fun: wri(board.NewKey[int]("a"), 1), nam: "a"}},
+				{Nam: "b", Han: &boardHandler{fun: wri(board.NewKey[int]("b"), 2), nam: "b"}},
+				{Nam: "c", Han: &boardHandler{fun: sum, nam: "c"}, Dep: []string{"a", "b"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	// Seed the graph execution with an input value and inspect the output value
+	// written by the last node.
+
+	var brd *board.Board
+	{
+		brd = board.New()
+	}
+
+	var ctx context.Context
+	{
+		ctx = board.With(context.Background(), brd)
+	}
+
+	{
+		err := board.Set(ctx, inp, 3)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := wor.EnsureContext(ctx)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
 	}
-}
 
-// Test_Worker_Sequence_Ensure_filter verifies that the *sequence.Worker does
-// not log filtered errors. Note that this test also covers the case of
-// Config.Coo to be empty, causing the worker engine to allocate a fake ticker
-// internally, which can be done to only use Worker.Ensure without
-// Worker.Daemon.
-func Test_Worker_Sequence_Ensure_filter(t *testing.T) {
-	var buf syncBuffer
+	{
+		val, _ := board.Get(ctx, out)
+		if val != 6 {
+			t.Fatal("expected", 6, "got", val)
+		}
+	}
+
+	// Without seeded board, every graph execution starts with a fresh board, so
+	// that the input value of the previous graph execution is not visible.
 
-	var sig chan int
 	{
-		sig = make(chan int, 5)
+		err := wor.Ensure()
+		if err == nil || !strings.Contains(err.Error(), "input must not be empty") {
+			t.Fatal("expected", "input must not be empty", "got", err)
+		}
+	}
+}
+
+// Test_Worker_Sequence_Ensure_graph verifies that the *sequence.Worker
+// executes every node as soon as its own dependencies completed, so that slow
+// nodes do not delay independent branches of the graph.
+func Test_Worker_Sequence_Ensure_graph(t *testing.T) {
+	var sig chan string
+	var rel chan struct{}
+	{
+		sig = make(chan string, 10)
+		rel = make(chan struct{})
 	}
 
 	var wor *Worker
 	{
 		wor = New(Config{
-			Han: [][]handler.Ensure{
-				{&errorHandler{sig, 3, nil}},
-				{&errorHandler{sig, 4, nil}},
-				{&errorHandler{sig, 5, errors.New("test error")}},
-				{&errorHandler{sig, 6, nil}},
-				{&errorHandler{sig, 7, nil}},
+			Log: logger.Fake(),
+			Nod: []Node{
+				{Nam: "d", Han: &graphHandler{sig: sig, nam: "d"}, Dep: []string{"a", "c"}},
+				{Nam: "a", Han: &graphHandler{sig: sig, nam: "a", rel: rel}},
+				{Nam: "b", Han: &graphHandler{sig: sig, nam: "b"}},
+				{Nam: "c", Han: &graphHandler{sig: sig, nam: "c"}, Dep: []string{"b"}},
 			},
-			Log: logger.New(logger.Config{
-				Filter: logger.NewLevelFilter("error"),
-				Writer: &buf,
-			}),
 			Reg: registry.New(registry.Config{
 				Env: "testing",
-				Fil: isErr,
 				Log: logger.Fake(),
 				Met: recorder.NewMeter(recorder.MeterConfig{
 					Env: "testing",
@@ -319,34 +1012,278 @@ func Test_Worker_Sequence_Ensure_filter(t *testing.T) {
 		})
 	}
 
+	var don chan error
 	{
-		wor.Daemon() // noop without cooler duration, would block otherwise
+		don = make(chan error, 1)
 	}
 
-	{
-		wor.ensure() // Ensure instead of Daemon
+	go func() {
+		don <- wor.Ensure()
+	}()
+
+	// Node a blocks until released, while b and c complete in the meantime.
+
+	var act []string
+	for range 3 {
+		act = append(act, <-sig)
 	}
 
-	var act []int
-	for x := range sig {
-		act = append(act, x)
+	{
+		slices.Sort(act)
 	}
 
 	{
-		exp := []int{3, 4, 5}
+		exp := []string{"a", "b", "c"}
 		if dif := cmp.Diff(exp, act); dif != "" {
 			t.Fatalf("-expected +actual:\n%s", dif)
 		}
 	}
 
+	// Node d only starts once a got released.
+
+	select {
+	case x := <-sig:
+		t.Fatal("expected", "no execution", "got", x)
+	case <-time.After(10 * time.Millisecond):
+	}
+
 	{
-		exp := ""
-		if dif := cmp.Diff(exp, buf.String()); dif != "" {
+		close(rel)
+	}
+
+	{
+		x := <-sig
+		if x != "d" {
+			t.Fatal("expected", "d", "got", x)
+		}
+	}
+
+	{
+		err := <-don
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	// The graph position of every node is the length of its longest dependency
+	// path.
+
+	var sta []int
+	for _, x := range wor.Status() {
+		sta = append(sta, x.Stage)
+	}
+
+	{
+		exp := []int{0, 0, 1, 2}
+		if dif := cmp.Diff(exp, sta); dif != "" {
 			t.Fatalf("-expected +actual:\n%s", dif)
 		}
 	}
 }
 
+// Test_Worker_Sequence_Ensure_limit verifies that the *sequence.Worker bounds
+// the number of concurrent node executions, both per stage and per resource
+// group.
+func Test_Worker_Sequence_Ensure_limit(t *testing.T) {
+	var cou *limitCounter
+	var gro *limitCounter
+	{
+		cou = &limitCounter{}
+		gro = &limitCounter{}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Max: 2,
+			Nod: []Node{
+				{Nam: "a", Han: &limitHandler{cou: cou, nam: "a"}},
+				{Nam: "b", Han: &limitHandler{cou: cou, nam: "b"}},
+				{Nam: "c", Han: &limitHandler{cou: cou, gro: gro, grp: "rpc", nam: "c"}},
+				{Nam: "d", Han: &limitHandler{cou: cou, gro: gro, grp: "rpc", nam: "d"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+			Sem: semaphore.New(semaphore.Config{
+				Lim: map[string]int{
+					"rpc": 1,
+				},
+			}),
+		})
+	}
+
+	for range 5 {
+		err := wor.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	if cou.total() != 20 {
+		t.Fatal("expected", 20, "got", cou.total())
+	}
+	if cou.peak() > 2 {
+		t.Fatal("expected", 2, "got", cou.peak())
+	}
+	if gro.peak() != 1 {
+		t.Fatal("expected", 1, "got", gro.peak())
+	}
+}
+
+// Test_Worker_Sequence_Ensure_policy verifies that the *sequence.Worker
+// applies the failure policies of failed nodes to their dependents, and that
+// the errors of all failed nodes are reported.
+func Test_Worker_Sequence_Ensure_policy(t *testing.T) {
+	testCases := []struct {
+		pol string
+		cer error
+		exe []string
+		fai []string
+	}{
+		// Case 000, dependents of a failed node are skipped
+		{
+			pol: PolicySkipDependents,
+			cer: nil,
+			exe: []string{"a", "c", "d"},
+			fai: []string{"a"},
+		},
+		// Case 001, dependents of a failed node are executed anyway
+		{
+			pol: PolicyContinue,
+			cer: nil,
+			exe: []string{"a", "b", "c", "d"},
+			fai: []string{"a"},
+		},
+		// Case 002, the graph execution is aborted
+		{
+			pol: PolicyFailFast,
+			cer: nil,
+			exe: []string{"a", "c"},
+			fai: []string{"a"},
+		},
+		// Case 003, the errors of all failed nodes are aggregated
+		{
+			pol: PolicySkipDependents,
+			cer: errors.New("test error"),
+			exe: []string{"a", "c"},
+			fai: []string{"a", "c"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			var sig chan string
+			{
+				sig = make(chan string, 10)
+			}
+
+			// Node c is already in-flight when node a fails, so that only node d
+			// can be affected by a fail-fast policy of node a.
+
+			var wor *Worker
+			{
+				wor = New(Config{
+					Log: logger.Fake(),
+					Nod: []Node{
+						{Nam: "a", Han: &policyHandler{sig: sig, nam: "a", err: errors.New("test error"), del: 10 * time.Millisecond}, Pol: tc.pol},
+						{Nam: "b", Han: &policyHandler{sig: sig, nam: "b"}, Dep: []string{"a"}},
+						{Nam: "c", Han: &policyHandler{sig: sig, nam: "c", err: tc.cer, del: 40 * time.Millisecond}},
+						{Nam: "d", Han: &policyHandler{sig: sig, nam: "d"}, Dep: []string{"c"}},
+					},
+					Reg: registry.New(registry.Config{
+						Env: "testing",
+						Log: logger.Fake(),
+						Met: recorder.NewMeter(recorder.MeterConfig{
+							Env: "testing",
+							Sco: "workit",
+							Ver: "v0.1.0",
+						}),
+					}),
+				})
+			}
+
+			var err error
+			{
+				err = wor.Ensure()
+			}
+
+			{
+				close(sig)
+			}
+
+			var exe []string
+			for x := range sig {
+				exe = append(exe, x)
+			}
+
+			{
+				slices.Sort(exe)
+			}
+
+			if dif := cmp.Diff(tc.exe, exe); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			var fai []string
+			{
+				var gra *GraphError
+				if errors.As(err, &gra) {
+					fai = slices.Sorted(maps.Keys(gra.Err))
+				} else if err != nil {
+					fai = []string{"a"}
+				}
+			}
+
+			if dif := cmp.Diff(tc.fai, fai); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+// Test_Worker_Sequence_Ensure_graph_error verifies that the *sequence.Worker
+// reports the failed nodes of ordered stages by handler name.
+func Test_Worker_Sequence_Ensure_graph_error(t *testing.T) {
+	var wor *Worker
+	{
+		wor = New(Config{
+			Han: [][]handler.Ensure{
+				{
+					&statusHandler{act: true, err: errors.New("test error"), nam: "foo"},
+					&statusHandler{act: true, err: errors.New("test error"), nam: "bar"},
+				},
+			},
+			Log: logger.Fake(),
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	var gra *GraphError
+	if !errors.As(wor.Ensure(), &gra) {
+		t.Fatal("expected", "*sequence.GraphError", "got", nil)
+	}
+
+	if dif := cmp.Diff([]string{"bar", "foo"}, slices.Sorted(maps.Keys(gra.Err))); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
 //
 //
 //
@@ -414,6 +1351,10 @@ func (h *activeHandler) Active() bool {
 	return h.act
 }
 
+func (h *activeHandler) Name() string {
+	return fmt.Sprintf("active%d", h.num)
+}
+
 func (h *activeHandler) Ensure() error {
 	{
 		h.sig <- h.num
@@ -430,6 +1371,43 @@ func (h *activeHandler) Ensure() error {
 //
 //
 
+// blockHandler blocks forever, regardless of any execution context, in order
+// to simulate a hanging worker handler.
+type blockHandler struct{}
+
+func (h *blockHandler) Active() bool {
+	return true
+}
+
+func (h *blockHandler) Ensure() error {
+	select {}
+}
+
+//
+//
+//
+
+type boardHandler struct {
+	fun func(context.Context) error
+	nam string
+}
+
+func (h *boardHandler) Active() bool {
+	return true
+}
+
+func (h *boardHandler) Name() string {
+	return h.nam
+}
+
+func (h *boardHandler) Ensure() error {
+	return nil
+}
+
+func (h *boardHandler) EnsureContext(ctx context.Context) error {
+	return h.fun(ctx)
+}
+
 type errorHandler struct {
 	sig chan int
 	num int
@@ -440,6 +1418,10 @@ func (h *errorHandler) Active() bool {
 	return true
 }
 
+func (h *errorHandler) Name() string {
+	return fmt.Sprintf("error%d", h.num)
+}
+
 func (h *errorHandler) Ensure() error {
 	{
 		h.sig <- h.num
@@ -457,6 +1439,136 @@ func (h *errorHandler) Ensure() error {
 //
 //
 
+type graphHandler struct {
+	nam string
+	rel chan struct{}
+	sig chan string
+}
+
+func (h *graphHandler) Active() bool {
+	return true
+}
+
+func (h *graphHandler) Name() string {
+	return h.nam
+}
+
+func (h *graphHandler) Ensure() error {
+	h.sig <- h.nam
+
+	if h.rel != nil {
+		<-h.rel
+	}
+
+	return nil
+}
+
+type leaderHandler struct {
+	cou int
+	loc locker.Interface
+}
+
+func (h *leaderHandler) Active() bool {
+	return true
+}
+
+func (h *leaderHandler) Ensure() error {
+	h.cou++
+	return nil
+}
+
+func (h *leaderHandler) Locker() locker.Interface {
+	return h.loc
+}
+
+// lostLocker grants the lease once, and loses it on the first renewal.
+type lostLocker struct {
+	cou int
+	mut sync.Mutex
+}
+
+func (l *lostLocker) Acquire(ctx context.Context, key string) (bool, error) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.cou++
+	return l.cou == 1, nil
+}
+
+func (l *lostLocker) Release(ctx context.Context, key string) error {
+	return nil
+}
+
+type limitCounter struct {
+	cur int
+	mut sync.Mutex
+	pea int
+	tot int
+}
+
+func (c *limitCounter) inc() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.cur++
+	c.tot++
+	c.pea = max(c.pea, c.cur)
+}
+
+func (c *limitCounter) dec() {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.cur--
+}
+
+func (c *limitCounter) peak() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.pea
+}
+
+func (c *limitCounter) total() int {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.tot
+}
+
+type limitHandler struct {
+	cou *limitCounter
+	gro *limitCounter
+	grp string
+	nam string
+}
+
+func (h *limitHandler) Active() bool {
+	return true
+}
+
+func (h *limitHandler) Name() string {
+	return h.nam
+}
+
+func (h *limitHandler) Ensure() error {
+	h.cou.inc()
+	defer h.cou.dec()
+
+	if h.gro != nil {
+		h.gro.inc()
+		defer h.gro.dec()
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	return nil
+}
+
+func (h *limitHandler) Group() string {
+	return h.grp
+}
+
 type orderHandler struct {
 	sig chan int
 	num int
@@ -467,6 +1579,10 @@ func (h *orderHandler) Active() bool {
 	return true
 }
 
+func (h *orderHandler) Name() string {
+	return fmt.Sprintf("order%d", h.num)
+}
+
 func (h *orderHandler) Ensure() error {
 	{
 		h.sig <- h.num
@@ -483,6 +1599,89 @@ func (h *orderHandler) Ensure() error {
 //
 //
 
+// stopHandler signals once it is in-flight and waits to be released. The
+// handler returns after its release if blo is false. Otherwise the handler
+// blocks until its execution context got cancelled. Either way, the state of
+// the execution context is reported upon return.
+type policyHandler struct {
+	del time.Duration
+	err error
+	nam string
+	sig chan string
+}
+
+func (h *policyHandler) Active() bool {
+	return true
+}
+
+func (h *policyHandler) Name() string {
+	return h.nam
+}
+
+func (h *policyHandler) Ensure() error {
+	time.Sleep(h.del)
+	h.sig <- h.nam
+	return h.err
+}
+
+type statusHandler struct {
+	act bool
+	err error
+	nam string
+}
+
+func (h *statusHandler) Active() bool {
+	return h.act
+}
+
+func (h *statusHandler) Name() string {
+	return h.nam
+}
+
+func (h *statusHandler) Ensure() error {
+	return h.err
+}
+
+type stopHandler struct {
+	sig chan struct{}
+	rel chan struct{}
+	out chan error
+	blo bool
+}
+
+func (h *stopHandler) Active() bool {
+	return true
+}
+
+func (h *stopHandler) Cooler() time.Duration {
+	return time.Hour
+}
+
+func (h *stopHandler) Ensure() error {
+	return nil
+}
+
+func (h *stopHandler) EnsureContext(ctx context.Context) error {
+	{
+		h.sig <- struct{}{}
+		<-h.rel
+	}
+
+	if h.blo {
+		<-ctx.Done()
+	}
+
+	{
+		h.out <- ctx.Err()
+	}
+
+	return nil
+}
+
+//
+//
+//
+
 // syncBuffer is needed to synchronize the concurrent io.Writer operations
 // related to the logger interface that we are testing against.
 type syncBuffer struct {
@@ -1,9 +1,19 @@
 package sequence
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/0xSplits/workit/board"
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/status"
 	"github.com/xh3b4sd/tracer"
-	"golang.org/x/sync/errgroup"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Ensure executes a single reconciliation loop of the directed acyclic graph.
@@ -11,6 +21,14 @@ import (
 // sequence of worker handlers continuously, but also to enable users to run
 // this sequence once in a controlled fashion.
 func (w *Worker) Ensure() error {
+	return w.EnsureContext(context.Background())
+}
+
+// EnsureContext executes a single reconciliation loop of the directed acyclic
+// graph like Ensure, but provides the given context to all worker handlers
+// implementing handler.EnsureContext. No further step of the graph is executed
+// once the given context got cancelled.
+func (w *Worker) EnsureContext(ctx context.Context) error {
 	// After every the graph execution, reset the internal ticker so that we sleep
 	// again for the configured wait duration. Doing this here enables the user to
 	// call Worker.Ensure externally on demand and maintain the desired schedule
@@ -20,15 +38,70 @@ func (w *Worker) Ensure() error {
 		defer w.tic.Reset()
 	}
 
-	for _, x := range w.han {
-		var err error
+	// After every graph execution, all worker handlers are expected to be
+	// executed again once the configured cooler duration passed.
+
+	{
+		defer w.cooling(w.coo)
+	}
 
-		if len(x) == 1 {
-			err = w.ensSeq(x) // execute a single worker handler
-		} else {
-			err = w.ensPar(x) // execute all worker handlers concurrently
+	// Skip the entire graph execution if leader election is enabled and this
+	// worker engine does not hold the lease of the graph.
+
+	if w.loc != nil {
+		lea, err := w.lea.Ensure(ctx, w.loc, w.nam)
+		if err != nil {
+			return tracer.Mask(err)
+		}
+		if !lea {
+			return nil
 		}
+	}
+
+	// Keep renewing the lease of the graph during the graph execution, so that
+	// the graph execution gets cancelled once the lease got lost.
+
+	var hol func() error
+	{
+		ctx, hol = w.lea.Hold(ctx, w.loc, w.nam)
+	}
 
+	// Provide a fresh board to every graph execution, so that worker handlers can
+	// pass data to their dependents without leaking it into the next graph
+	// execution. A board carried by the given context is used as is, so that the
+	// caller can seed and inspect the data of a single graph execution.
+
+	if board.From(ctx) == nil {
+		ctx = board.With(ctx, board.New())
+	}
+
+	// Notify the observer about the entire graph execution, so that e.g. the
+	// end to end latency of the graph can be tracked.
+
+	var sta time.Time
+	{
+		sta = time.Now()
+	}
+
+	{
+		w.obs.OnGraphStart(w.nam)
+	}
+
+	// Trace the entire graph execution within a parent span, so that the spans
+	// of all stages and all of their worker handlers join the same trace.
+
+	var spn trace.Span
+	{
+		ctx, spn = w.reg.Tracer().Start(ctx, w.nam, trace.WithAttributes(attribute.String("pipeline", w.nam)))
+	}
+
+	{
+		err := w.graph(ctx)
+		if los := hol(); los != nil {
+			err = los
+		}
+		w.obs.OnGraphEnd(w.nam, time.Since(sta), err)
+		w.failed(spn, err, time.Now())
 		if err != nil {
 			return tracer.Mask(err)
 		}
@@ -37,80 +110,385 @@ func (w *Worker) Ensure() error {
 	return nil
 }
 
-func (w *Worker) ensPar(han []handler.Interface) error {
-	var grp errgroup.Group
+// graph executes all nodes of the directed acyclic graph, each in their own
+// goroutine. Every node waits for all of its dependencies to complete before
+// being executed, so that independent branches of the graph do not delay each
+// other. Whether a node is executed after any of its dependencies failed
+// depends on the failure policies of those dependencies. No further node is
+// executed once the given context got cancelled. The errors of all failed
+// nodes are returned once all nodes completed or got skipped.
+func (w *Worker) graph(ctx context.Context) error {
+	var sta time.Time
 	{
-		grp = errgroup.Group{}
+		sta = time.Now()
 	}
 
-	// Bootstrap a static worker pool of N goroutines, where N is the number of
-	// injected worker handlers for this iteration. This parallel execution
-	// isolates handler specific failure domains. Each handler is executed along
-	// its own pipeline so that any handler specific runtime errors and execution
-	// delays cannot affect the execution of the other worker handlers during this
-	// iteration.
+	// Every node closes its done channel once it completed or got skipped. The
+	// result of every node is written before closing its done channel, so that
+	// all dependents can safely read it after receiving from it.
 
-	for _, x := range han {
-		// Continue with the next worker handler without doing any work for this
-		// specific worker handler if this worker handler declares itself as not
-		// active for this reconciliation loop.
+	var don []chan struct{}
+	for range w.nod {
+		don = append(don, make(chan struct{}))
+	}
 
-		if !x.Active() {
-			continue
-		}
+	var res []string
+	{
+		res = make([]string, len(w.nod))
+	}
+
+	// Every executed node records the time it got ready for execution, and the
+	// time it completed, so that the duration of every stage can be measured.
+	// The first stage that did not complete is tracked for aborted graph
+	// executions.
+
+	var beg []time.Time
+	var end []time.Time
+	{
+		beg = make([]time.Time, len(w.nod))
+		end = make([]time.Time, len(w.nod))
+	}
 
-		grp.Go(func() error {
-			// Note that our worker handlers may be wrapped. So we have to call unwrap
-			// before resolving the implementation's identifier in the error case.
+	// Every stage is traced within its own span, which is started once the first
+	// node of the stage is ready for execution, so that the spans of all worker
+	// handlers of the stage become its children.
+
+	var stc []context.Context
+	var sts []trace.Span
+	var ste []error
+	{
+		stc = make([]context.Context, len(w.rec))
+		sts = make([]trace.Span, len(w.rec))
+		ste = make([]error, len(w.rec))
+	}
+
+	var abo error
+	var abs int
+	var fai map[string]error
+	var grp sync.WaitGroup
+	var mut sync.Mutex
+	{
+		abs = -1
+		fai = map[string]error{}
+	}
+
+	for i, x := range w.nod {
+		grp.Add(1)
+		go func() {
+			defer grp.Done()
+			defer close(don[i])
+
+			for _, y := range x.dep {
+				<-don[y]
+			}
+
+			{
+				res[i] = resultSkipped
+			}
+
+			// Skip this node if any of its dependencies got skipped, or if any of its
+			// dependencies failed without permitting its dependents to continue. The
+			// stage of the failed dependency is tracked, because it is the stage
+			// that did not complete, causing its dependents to be skipped.
+
+			for _, y := range x.dep {
+				if res[y] == resultSkipped {
+					w.obs.OnSkip(x.rec.nam)
+					return
+				}
+				if res[y] == resultFailed && w.nod[y].pol != PolicyContinue {
+					mut.Lock()
+					abs = first(abs, w.nod[y].stg)
+					mut.Unlock()
+
+					w.obs.OnSkip(x.rec.nam)
+					return
+				}
+			}
+
+			// Skip this node if the graph execution got aborted, either because a
+			// node failed fast, or because the given context got cancelled.
+
+			mut.Lock()
+			if abo == nil && ctx.Err() != nil {
+				abo = tracer.Mask(ctx.Err())
+			}
+			ski := abo != nil
+			if ski {
+				abs = first(abs, x.stg)
+			}
+			mut.Unlock()
+
+			if ski {
+				w.obs.OnSkip(x.rec.nam)
+				return
+			}
+
+			mut.Lock()
+			if sts[x.stg] == nil {
+				stc[x.stg], sts[x.stg] = w.reg.Tracer().Start(ctx, fmt.Sprintf("stage %d", x.stg), trace.WithAttributes(attribute.Int("stage", x.stg)))
+			}
+			sct := stc[x.stg]
+			mut.Unlock()
+
+			{
+				beg[i] = time.Now()
+			}
+
+			err := w.run(sct, x)
+
+			{
+				end[i] = time.Now()
+			}
+
+			// Track the stage of failed nodes that abort the graph execution right
+			// away, either because they fail fast, or because the given context got
+			// cancelled. Other failures are only tracked once they cause their
+			// dependents to be skipped.
 
-			err := x.Ensure()
 			if err != nil {
-				return tracer.Mask(err, tracer.Context{Key: "handler", Value: handler.Name(x.Unwrap())})
+				mut.Lock()
+				fai[x.nam] = err
+				if x.pol == PolicyFailFast {
+					abo = err
+				}
+				if x.pol == PolicyFailFast || ctx.Err() != nil {
+					abs = first(abs, x.stg)
+				}
+				ste[x.stg] = errors.Join(ste[x.stg], err)
+				mut.Unlock()
+
+				res[i] = resultFailed
+
+				return
 			}
 
-			return nil
-		})
+			{
+				res[i] = resultSucceeded
+			}
+		}()
 	}
 
 	{
-		err := grp.Wait()
-		if err != nil {
-			return tracer.Mask(err)
+		grp.Wait()
+	}
+
+	var fir map[int]time.Time
+	var las map[int]time.Time
+	{
+		fir, las = stages(w.nod, beg, end)
+	}
+
+	{
+		w.instrument(sta, fir, las, len(fai) != 0, abo != nil, abs)
+	}
+
+	for i, x := range sts {
+		if x != nil {
+			w.failed(x, ste[i], las[i])
 		}
 	}
 
+	// Return the error of a single failed node as is, and aggregate the errors
+	// of multiple failed nodes, so that every failed node is reported. The
+	// context error is only returned if no node failed.
+
+	if len(fai) == 1 {
+		for _, v := range fai {
+			return tracer.Mask(v)
+		}
+	}
+
+	if len(fai) > 1 {
+		return tracer.Mask(&GraphError{Err: fai})
+	}
+
+	if abo != nil {
+		return tracer.Mask(abo)
+	}
+
 	return nil
 }
 
-func (w *Worker) ensSeq(han []handler.Interface) error {
-	var x handler.Interface
+// run executes the worker handler of the given node, unless the worker handler
+// got paused, declares itself as not active for this reconciliation loop, or
+// opted into leader election without this worker engine holding its lease. The
+// execution waits for the concurrency limits of the node, if any. The runtime
+// state of the worker handler is recorded along the way.
+func (w *Worker) run(ctx context.Context, nod *node) error {
+	var han handler.Interface
+	var rec *record
 	{
-		x = han[0] // the factory at sequence.New must validate against empty steps
+		han = nod.han
+		rec = nod.rec
 	}
 
-	// Return early without doing any work if this worker handler declares itself
-	// as not active for this reconciliation loop.
+	var pau bool
+	w.state(rec, func(r *record) { pau = r.pau })
 
-	if !x.Active() {
+	if pau {
+		w.state(rec, func(r *record) { r.sta = status.StateDisabled })
+		w.obs.OnSkip(rec.nam)
 		return nil
 	}
 
-	// Note that our worker handlers may be wrapped. So we have to call unwrap
-	// before resolving the implementation's identifier in the error case.
+	var act bool
+	{
+		act = han.Active()
+	}
+
+	w.state(rec, func(r *record) { r.act = act })
+
+	if !act {
+		w.state(rec, func(r *record) { r.sta = status.StateDisabled })
+		w.obs.OnSkip(rec.nam)
+		return nil
+	}
 
-	err := x.Ensure()
+	lea, err := w.leader(ctx, han)
 	if err != nil {
-		return tracer.Mask(err, tracer.Context{Key: "handler", Value: handler.Name(x.Unwrap())})
+		return tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam})
+	}
+	if !lea {
+		w.obs.OnSkip(rec.nam)
+		return nil
+	}
+
+	rel, err := w.acquire(ctx, nod)
+	if err != nil {
+		return tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam})
+	}
+
+	{
+		defer rel()
+	}
+
+	var sta time.Time
+	{
+		sta = time.Now()
+	}
+
+	w.state(rec, func(r *record) { r.sta = status.StateRunning })
+	w.obs.OnStart(rec.nam)
+
+	// Keep renewing the lease of the worker handler during its execution, if
+	// any, so that the execution gets cancelled once the lease got lost.
+
+	hct, hol := w.lea.Hold(ctx, han.Locker(), handler.Name(han.Unwrap()))
+
+	{
+		err = w.exe.Execute(hct, rec.nam, han)
+	}
+
+	if los := hol(); los != nil {
+		err = los
+	}
+
+	var dur time.Duration
+	{
+		dur = time.Since(sta)
+	}
+
+	w.state(rec, func(r *record) {
+		r.dur = dur
+		r.err = err
+		r.sta = status.StateCooling
+	})
+
+	if err != nil {
+		w.obs.OnError(rec.nam, dur, err)
+		return tracer.Mask(err, tracer.Context{Key: "handler", Value: rec.nam})
+	}
+
+	{
+		w.obs.OnSuccess(rec.nam, dur)
 	}
 
 	return nil
 }
 
-func (w *Worker) ensure() {
-	err := w.Ensure()
+// cooling records the given cooler duration of all worker handlers for health
+// reporting and runtime introspection.
+func (w *Worker) cooling(coo time.Duration) {
+	for _, x := range w.rec {
+		for _, y := range x {
+			w.hea.Cooling(y.nam, coo)
+			w.state(y, func(r *record) { r.coo = coo })
+		}
+	}
+}
+
+// instrument records the outcome and the duration of the graph execution that
+// started at the given time, as well as the duration of all stages that had
+// any node executed.
+func (w *Worker) instrument(sta time.Time, fir map[int]time.Time, las map[int]time.Time, fai bool, abo bool, abs int) {
+	var out string
+	if fai {
+		out = OutcomeFailure
+	} else if abo {
+		out = OutcomeCancelled
+	} else {
+		out = OutcomeSuccess
+	}
+
+	{
+		w.insRun(out, time.Since(sta), abs)
+	}
+
+	for k, v := range fir {
+		w.insStg(k, las[k].Sub(v))
+	}
+}
+
+// stages returns the time that the first node of every stage got ready for
+// execution, and the time that the last node of every stage completed, keyed
+// by stage. Stages without any executed node are omitted.
+func stages(nod []*node, beg []time.Time, end []time.Time) (map[int]time.Time, map[int]time.Time) {
+	fir := map[int]time.Time{}
+	las := map[int]time.Time{}
+
+	for i, x := range nod {
+		if beg[i].IsZero() {
+			continue
+		}
+
+		if f, e := fir[x.stg]; !e || beg[i].Before(f) {
+			fir[x.stg] = beg[i]
+		}
+		if l, e := las[x.stg]; !e || end[i].After(l) {
+			las[x.stg] = end[i]
+		}
+	}
+
+	return fir, las
+}
+
+// failed records the given error on the given span, if any, and ends the given
+// span at the given time. Note that filtered errors are recorded, but do not
+// mark the span as failed, just like the spans of the worker handlers.
+func (w *Worker) failed(spn trace.Span, err error, end time.Time) {
+	if err != nil {
+		spn.RecordError(err)
+	}
+
+	if err != nil && !w.reg.Log(err) {
+		spn.SetStatus(codes.Error, err.Error())
+	}
+
+	{
+		spn.End(trace.WithTimestamp(end))
+	}
+}
+
+func (w *Worker) ensure(ctx context.Context) {
+	err := w.EnsureContext(ctx)
 	if err != nil && !w.reg.Log(err) {
 		w.error(tracer.Mask(err)) // only log if not filtered
 	}
+
+	{
+		w.obs.OnCoolerStart(w.nam, w.coo)
+	}
 }
 
 func (w *Worker) error(err error) {
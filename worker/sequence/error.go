@@ -1,5 +1,27 @@
 package sequence
 
+import (
+	"errors"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+var graphCycleError = &tracer.Error{
+	Description: "The directed acyclic graph must not contain cycles, but the listed nodes are part of, or depend on, a cycle.",
+}
+
+func isGraphCycle(err error) bool {
+	return errors.Is(err, graphCycleError)
+}
+
+var missingDependencyError = &tracer.Error{
+	Description: "The node of the directed acyclic graph depends on a node that does not exist.",
+}
+
+func isMissingDependency(err error) bool {
+	return errors.Is(err, missingDependencyError)
+}
+
 // isErr is only used for testing purposes.
 func isErr(err error) bool {
 	return err != nil
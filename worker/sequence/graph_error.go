@@ -0,0 +1,51 @@
+package sequence
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GraphError aggregates the errors of all nodes that failed during a single
+// graph execution. GraphError is only returned if more than one node failed,
+// otherwise the error of the single failed node is returned as is. Use
+// errors.As to inspect the errors of all failed nodes.
+type GraphError struct {
+	// Err is the error of every failed node, keyed by node name.
+	Err map[string]error
+}
+
+// Error returns the names and the errors of all failed nodes, sorted by node
+// name.
+func (e *GraphError) Error() string {
+	var lis []string
+	for _, x := range e.names() {
+		lis = append(lis, fmt.Sprintf("%s: %s", x, e.Err[x].Error()))
+	}
+
+	return fmt.Sprintf("%d nodes failed: %s", len(lis), strings.Join(lis, "; "))
+}
+
+// Unwrap returns the errors of all failed nodes, sorted by node name, so that
+// errors.Is and errors.As match any of them.
+func (e *GraphError) Unwrap() []error {
+	var lis []error
+	for _, x := range e.names() {
+		lis = append(lis, e.Err[x])
+	}
+
+	return lis
+}
+
+func (e *GraphError) names() []string {
+	var nam []string
+	for k := range e.Err {
+		nam = append(nam, k)
+	}
+
+	{
+		slices.Sort(nam)
+	}
+
+	return nam
+}
@@ -0,0 +1,26 @@
+package sequence
+
+import (
+	"context"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// leader returns whether this worker engine holds the lease of the given worker
+// handler. Only worker handlers implementing handler.Locker opt into leader
+// election individually, because the locker of this worker engine guards the
+// entire graph execution already.
+func (w *Worker) leader(ctx context.Context, han handler.Interface) (bool, error) {
+	loc := han.Locker()
+	if loc == nil {
+		return true, nil
+	}
+
+	lea, err := w.lea.Ensure(ctx, loc, handler.Name(han.Unwrap()))
+	if err != nil {
+		return false, tracer.Mask(err)
+	}
+
+	return lea, nil
+}
@@ -0,0 +1,89 @@
+package sequence
+
+import (
+	"context"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// acquire blocks until the given node may be executed within the concurrency
+// limits of its resource group, if any, and of its stage, if any. Waiting is
+// aborted once the given context got cancelled. The returned function must be
+// called once the execution of the given node finished, so that the acquired
+// slots are freed again, as soon as any abandoned execution of the given node
+// returned as well.
+func (w *Worker) acquire(ctx context.Context, nod *node) (func(), error) {
+	// Acquire the slot of the resource group first, so that the slots of the
+	// stage are only occupied by nodes that can be executed right away.
+	// Acquiring in the same order everywhere prevents deadlocks.
+
+	var grp string
+	{
+		grp = nod.han.Group()
+	}
+
+	if grp != "" {
+		err := w.sem.Acquire(ctx, grp)
+		if err != nil {
+			return nil, tracer.Mask(err)
+		}
+	}
+
+	if nod.lim != nil {
+		select {
+		case nod.lim <- struct{}{}:
+		case <-ctx.Done():
+			if grp != "" {
+				w.sem.Release(grp)
+			}
+
+			return nil, tracer.Mask(ctx.Err())
+		}
+	}
+
+	rel := func() {
+		if nod.lim != nil {
+			<-nod.lim
+		}
+
+		if grp != "" {
+			w.sem.Release(grp)
+		}
+	}
+
+	return settle(nod.han, rel), nil
+}
+
+// settle returns a function calling the given release function once the given
+// worker handler settled. Executions abandoned by the wrapped proxy handler may
+// keep running in the background, in which case their acquired slots must not
+// be freed before they finally returned. Otherwise the concurrency limits could
+// be exceeded by abandoned executions.
+func settle(han handler.Interface, rel func()) func() {
+	return func() {
+		var pen <-chan struct{}
+		for _, x := range handler.Stack(han) {
+			p, i := x.(handler.Pending)
+			if i {
+				pen = p.Pending()
+				break
+			}
+		}
+
+		if pen == nil {
+			rel()
+			return
+		}
+
+		select {
+		case <-pen:
+			rel()
+		default:
+			go func() {
+				<-pen
+				rel()
+			}()
+		}
+	}
+}
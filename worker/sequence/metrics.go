@@ -0,0 +1,184 @@
+package sequence
+
+import (
+	"strconv"
+	"time"
+
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/registry"
+	"github.com/xh3b4sd/tracer"
+)
+
+const (
+	MetricAborted  = "worker_graph_aborted_total"
+	MetricCooler   = "worker_graph_cooler_duration_seconds"
+	MetricDuration = "worker_graph_run_duration_seconds"
+	MetricStage    = "worker_graph_stage_duration_seconds"
+	MetricTotal    = "worker_graph_run_total"
+)
+
+const (
+	// OutcomeCancelled is the outcome of graph executions that got aborted,
+	// because the execution context got cancelled, without any node failing.
+	OutcomeCancelled = "cancelled"
+
+	// OutcomeFailure is the outcome of graph executions with failed nodes.
+	OutcomeFailure = "failure"
+
+	// OutcomeSuccess is the outcome of graph executions without failed nodes.
+	OutcomeSuccess = "success"
+)
+
+// metrics returns the metrics registry instrumenting the graph executions of
+// the given pipeline, where the given amount of stages is whitelisted.
+func metrics(reg *registry.Registry, nam string, stg int) otelreg.Interface {
+	var sta []string
+	for i := range stg {
+		sta = append(sta, strconv.Itoa(i))
+	}
+
+	var buc []float64
+	{
+		buc = []float64{
+			0.1,
+			0.5,
+			1,
+			5,
+			10,
+			30,
+			60,
+			300,
+			600,
+			1800,
+		}
+	}
+
+	cou := map[string]registry.Metric{}
+	gau := map[string]registry.Metric{}
+	his := map[string]registry.Metric{}
+
+	{
+		cou[MetricAborted] = registry.Metric{
+			Des: "the total amount of aborted graph executions by the first stage that did not complete",
+			Lab: map[string][]string{
+				"pipeline": {nam},
+				"stage":    sta,
+			},
+		}
+		cou[MetricTotal] = registry.Metric{
+			Des: "the total amount of graph executions by outcome",
+			Lab: map[string][]string{
+				"outcome":  {OutcomeCancelled, OutcomeFailure, OutcomeSuccess},
+				"pipeline": {nam},
+			},
+		}
+	}
+
+	{
+		his[MetricCooler] = registry.Metric{
+			Buc: []float64{
+				1,
+				5,
+				10,
+				30,
+				60,
+				300,
+				900,
+				3600,
+			},
+			Des: "the time that the graph slept between executions",
+			Lab: map[string][]string{
+				"pipeline": {nam},
+			},
+		}
+		his[MetricDuration] = registry.Metric{
+			Buc: buc,
+			Des: "the time it takes for graph executions to complete",
+			Lab: map[string][]string{
+				"outcome":  {OutcomeCancelled, OutcomeFailure, OutcomeSuccess},
+				"pipeline": {nam},
+			},
+		}
+		his[MetricStage] = registry.Metric{
+			Buc: buc,
+			Des: "the time it takes for all executed nodes of a graph stage to complete",
+			Lab: map[string][]string{
+				"pipeline": {nam},
+				"stage":    sta,
+			},
+		}
+	}
+
+	return reg.Metrics(cou, gau, his)
+}
+
+// insCoo records the time that the graph actually slept between executions,
+// which may be shorter than the configured cooler if it got triggered.
+func (w *Worker) insCoo(dur time.Duration) {
+	lab := map[string]string{
+		"pipeline": w.nam,
+	}
+
+	err := w.met.Histogram(MetricCooler, dur.Seconds(), lab)
+	if err != nil {
+		w.instrumentation(err)
+	}
+}
+
+// insRun records the outcome and the duration of a single graph execution.
+// The first stage that did not complete is recorded for graph executions that
+// got aborted, either because a node failed fast, because the execution context
+// got cancelled, or because a failed node caused its dependents to be skipped.
+func (w *Worker) insRun(out string, dur time.Duration, abo int) {
+	{
+		lab := map[string]string{
+			"outcome":  out,
+			"pipeline": w.nam,
+		}
+
+		err := w.met.Counter(MetricTotal, 1, lab)
+		if err != nil {
+			w.instrumentation(err)
+		}
+
+		err = w.met.Histogram(MetricDuration, dur.Seconds(), lab)
+		if err != nil {
+			w.instrumentation(err)
+		}
+	}
+
+	if out != OutcomeSuccess && abo >= 0 {
+		lab := map[string]string{
+			"pipeline": w.nam,
+			"stage":    strconv.Itoa(abo),
+		}
+
+		err := w.met.Counter(MetricAborted, 1, lab)
+		if err != nil {
+			w.instrumentation(err)
+		}
+	}
+}
+
+// insStg records the duration of the given stage, which is the time between
+// the first node of the stage being ready for execution, and the last node of
+// the stage completing its execution.
+func (w *Worker) insStg(stg int, dur time.Duration) {
+	lab := map[string]string{
+		"pipeline": w.nam,
+		"stage":    strconv.Itoa(stg),
+	}
+
+	err := w.met.Histogram(MetricStage, dur.Seconds(), lab)
+	if err != nil {
+		w.instrumentation(err)
+	}
+}
+
+func (w *Worker) instrumentation(err error) {
+	w.log.Log(
+		"level", "error",
+		"message", "worker instrumentation failed",
+		"stack", tracer.Json(err),
+	)
+}
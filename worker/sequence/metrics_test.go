@@ -0,0 +1,231 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Worker_Sequence_Ensure_graph_metrics verifies that the *sequence.Worker
+// instruments every graph execution as a whole, labelled with its pipeline
+// name, including the duration of every executed stage, and the first stage
+// that did not complete.
+func Test_Worker_Sequence_Ensure_graph_metrics(t *testing.T) {
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var fai error
+	{
+		fai = errors.New("test error")
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "a"})},
+				{Nam: "b", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return fai }, Nam: "b"}), Dep: []string{"a"}, Pol: PolicyFailFast},
+				{Nam: "c", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "c"}), Dep: []string{"b"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	// The first graph execution fails fast in stage 1, so that stage 2 is
+	// skipped. The second graph execution succeeds.
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "test error", "got", nil)
+		}
+	}
+
+	{
+		fai = nil
+	}
+
+	{
+		err := wor.Ensure()
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	var res string
+	{
+		res = tesRes(url)
+	}
+
+	testCases := []string{
+		`worker_graph_aborted_total\{env="testing",[^}]*pipeline="pipeline",stage="1"\} 1`,
+		`worker_graph_run_duration_seconds_count\{env="testing",[^}]*outcome="failure",pipeline="pipeline"\} 1`,
+		`worker_graph_run_duration_seconds_count\{env="testing",[^}]*outcome="success",pipeline="pipeline"\} 1`,
+		`worker_graph_run_total\{env="testing",[^}]*outcome="failure",pipeline="pipeline"\} 1`,
+		`worker_graph_run_total\{env="testing",[^}]*outcome="success",pipeline="pipeline"\} 1`,
+		`worker_graph_stage_duration_seconds_count\{env="testing",[^}]*pipeline="pipeline",stage="0"\} 2`,
+		`worker_graph_stage_duration_seconds_count\{env="testing",[^}]*pipeline="pipeline",stage="1"\} 2`,
+		`worker_graph_stage_duration_seconds_count\{env="testing",[^}]*pipeline="pipeline",stage="2"\} 1`,
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			if !regexp.MustCompile(tc).MatchString(res) {
+				t.Fatalf("expected %s to match\n%s", tc, res)
+			}
+		})
+	}
+}
+
+// Test_Worker_Sequence_Ensure_graph_metrics_continue verifies that failed nodes
+// permitting their dependents to continue do not count as aborted graph
+// executions.
+func Test_Worker_Sequence_Ensure_graph_metrics_continue(t *testing.T) {
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return errors.New("test error") }, Nam: "a"}), Pol: PolicyContinue},
+				{Nam: "b", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "b"}), Dep: []string{"a"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "test error", "got", nil)
+		}
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	var res string
+	{
+		res = tesRes(url)
+	}
+
+	if !regexp.MustCompile(`worker_graph_run_total\{env="testing",[^}]*outcome="failure",pipeline="pipeline"\} 1`).MatchString(res) {
+		t.Fatalf("expected failed graph execution in\n%s", res)
+	}
+
+	if regexp.MustCompile(`worker_graph_aborted_total`).MatchString(res) {
+		t.Fatalf("expected no aborted graph execution in\n%s", res)
+	}
+}
+
+// Test_Worker_Sequence_Ensure_graph_metrics_skip verifies that graph
+// executions are recorded as aborted under the default policy, once a failed
+// node causes its dependents to be skipped, labelled with the stage of the
+// failed node.
+func Test_Worker_Sequence_Ensure_graph_metrics_skip(t *testing.T) {
+	var reg *prometheus.Registry
+	{
+		reg = prometheus.NewRegistry()
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "a"})},
+				{Nam: "b", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return errors.New("test error") }, Nam: "b"}), Dep: []string{"a"}},
+				{Nam: "c", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "c"}), Dep: []string{"b"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Reg: reg,
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "test error", "got", nil)
+		}
+	}
+
+	var ser *httptest.Server
+	var url string
+	{
+		ser, url = tesSer(reg)
+	}
+
+	{
+		defer ser.Close()
+	}
+
+	var res string
+	{
+		res = tesRes(url)
+	}
+
+	if !regexp.MustCompile(`worker_graph_aborted_total\{env="testing",[^}]*pipeline="pipeline",stage="1"\} 1`).MatchString(res) {
+		t.Fatalf("expected aborted graph execution in\n%s", res)
+	}
+}
@@ -0,0 +1,170 @@
+package sequence
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/0xSplits/workit/handler"
+	"github.com/xh3b4sd/tracer"
+)
+
+// Node is a single worker handler within the directed acyclic graph executed
+// by the *sequence.Worker engine. Every node is executed as soon as all of the
+// nodes it depends on completed.
+type Node struct {
+	// Dep is the optional list of node names that this node depends on. Nodes
+	// without dependencies are executed right away when the graph starts.
+	Dep []string
+
+	// Han is the worker handler implementing the actual business logic of this
+	// node.
+	Han handler.Ensure
+
+	// Nam is the optional name of this node, which other nodes refer to when
+	// declaring their dependencies. Node names must be unique within the graph.
+	// Defaults to the handler name as returned by handler.Name.
+	Nam string
+
+	// Pol is the optional failure policy applied once this node failed, e.g.
+	// PolicyFailFast. Defaults to the failure policy of the worker engine.
+	Pol string
+}
+
+// node is the compiled representation of a Node, referring to its
+// dependencies by their position within the topologically sorted graph.
+type node struct {
+	dep []int
+	han handler.Interface
+	lim chan struct{}
+	nam string
+	pol string
+	rec *record
+	stg int
+}
+
+// compile converts the given list of stages into graph nodes, where every
+// node of a stage depends on all nodes of the previous stage. The generated
+// node names are the handler names as returned by handler.Name, so that e.g.
+// failed nodes are reported by handler name.
+func compile(han [][]handler.Ensure) []Node {
+	var nod []Node
+	var dep []string
+
+	for _, x := range han {
+		var nam []string
+
+		for j, y := range x {
+			nam = append(nam, handler.Name(y))
+			nod = append(nod, Node{Dep: dep, Han: y, Nam: nam[j]})
+		}
+
+		{
+			dep = nam
+		}
+	}
+
+	return nod
+}
+
+// sort returns the indices of the given nodes in topological order, so that
+// every node comes after all of its dependencies. sort returns an error if any
+// node declares a dependency that does not exist, or if the given nodes contain
+// a cycle.
+func sort(nod []Node) ([]int, error) {
+	var ind map[string]int
+	{
+		ind = map[string]int{}
+	}
+
+	for i, x := range nod {
+		ind[x.Nam] = i
+	}
+
+	// Count the unresolved dependencies of every node, and remember the reverse
+	// edges, so that we can resolve the dependents of every sorted node.
+
+	cou := make([]int, len(nod))
+	rev := make([][]int, len(nod))
+
+	for i, x := range nod {
+		for _, y := range x.Dep {
+			j, e := ind[y]
+			if !e {
+				return nil, tracer.Mask(missingDependencyError, tracer.Context{Key: "node", Value: x.Nam}, tracer.Context{Key: "dependency", Value: y})
+			}
+
+			cou[i]++
+			rev[j] = append(rev[j], i)
+		}
+	}
+
+	var que []int
+	for i := range nod {
+		if cou[i] == 0 {
+			que = append(que, i)
+		}
+	}
+
+	var ord []int
+	for len(que) != 0 {
+		var i int
+		{
+			i, que = que[0], que[1:]
+		}
+
+		{
+			ord = append(ord, i)
+		}
+
+		for _, j := range rev[i] {
+			cou[j]--
+			if cou[j] == 0 {
+				que = append(que, j)
+			}
+		}
+	}
+
+	// All nodes that could not be sorted are part of, or depend on, a cycle.
+
+	if len(ord) != len(nod) {
+		var cyc []string
+		for i, x := range nod {
+			if cou[i] != 0 {
+				cyc = append(cyc, x.Nam)
+			}
+		}
+
+		{
+			slices.Sort(cyc)
+		}
+
+		return nil, tracer.Mask(graphCycleError, tracer.Context{Key: "nodes", Value: strings.Join(cyc, ", ")})
+	}
+
+	return ord, nil
+}
+
+// depth returns the length of the longest dependency path leading to every
+// given node, indexed like the given nodes. The given order must be
+// topological.
+func depth(nod []*node) []int {
+	dep := make([]int, len(nod))
+
+	for i, x := range nod {
+		for _, y := range x.dep {
+			dep[i] = max(dep[i], dep[y]+1)
+		}
+	}
+
+	return dep
+}
+
+// first returns the lower of the given stages, where a negative stage means
+// that no stage has been tracked yet.
+func first(abs int, stg int) int {
+	if abs < 0 {
+		return stg
+	}
+
+	return min(abs, stg)
+}
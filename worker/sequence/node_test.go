@@ -0,0 +1,186 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/status"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+func Test_Worker_Sequence_compile(t *testing.T) {
+	var han [][]handler.Ensure
+	{
+		han = [][]handler.Ensure{
+			{&statusHandler{nam: "a"}},
+			{&statusHandler{nam: "b"}, &statusHandler{nam: "c"}},
+			{&statusHandler{nam: "d"}},
+		}
+	}
+
+	var nam [][]string
+	for _, x := range compile(han) {
+		nam = append(nam, append([]string{x.Nam}, x.Dep...))
+	}
+
+	exp := [][]string{
+		{"a"},
+		{"b", "a"},
+		{"c", "a"},
+		{"d", "b", "c"},
+	}
+
+	if dif := cmp.Diff(exp, nam); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+}
+
+// Test_Worker_Sequence_New_nodes verifies that sequence.New does not modify
+// the given nodes, so that the same nodes can be reused for multiple worker
+// engines, and that worker handlers are addressed by their node names.
+func Test_Worker_Sequence_New_nodes(t *testing.T) {
+	var nod []Node
+	{
+		nod = []Node{
+			{Han: &statusHandler{act: true, nam: "foo"}},
+			{Han: &statusHandler{act: true, nam: "bar"}, Nam: "b"},
+		}
+	}
+
+	var wor *Worker
+	for _, x := range []string{PolicyFailFast, PolicyContinue} {
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nod: nod,
+			Pol: x,
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	if dif := cmp.Diff("", nod[0].Nam+nod[0].Pol+nod[1].Pol); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	if dif := cmp.Diff(PolicyContinue, wor.nod[0].pol); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	var nam []string
+	for _, x := range wor.Status() {
+		nam = append(nam, x.Name)
+	}
+
+	if dif := cmp.Diff([]string{"foo", "b"}, nam); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	{
+		err := wor.Pause("b")
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		err := wor.Pause("bar")
+		if !errors.Is(err, status.NotFoundError) {
+			t.Fatal("expected", status.NotFoundError, "got", err)
+		}
+	}
+}
+
+func Test_Worker_Sequence_sort(t *testing.T) {
+	testCases := []struct {
+		nod []Node
+		ord []int
+		mat func(error) bool
+	}{
+		// Case 000, independent nodes keep their order
+		{
+			nod: []Node{
+				{Nam: "a"},
+				{Nam: "b"},
+			},
+			ord: []int{0, 1},
+			mat: nil,
+		},
+		// Case 001, dependencies come first
+		{
+			nod: []Node{
+				{Nam: "a", Dep: []string{"c"}},
+				{Nam: "b", Dep: []string{"a", "c"}},
+				{Nam: "c"},
+			},
+			ord: []int{2, 0, 1},
+			mat: nil,
+		},
+		// Case 002, diamond
+		{
+			nod: []Node{
+				{Nam: "d", Dep: []string{"b", "c"}},
+				{Nam: "c", Dep: []string{"a"}},
+				{Nam: "b", Dep: []string{"a"}},
+				{Nam: "a"},
+			},
+			ord: []int{3, 1, 2, 0},
+			mat: nil,
+		},
+		// Case 003, missing dependency
+		{
+			nod: []Node{
+				{Nam: "a"},
+				{Nam: "b", Dep: []string{"x"}},
+			},
+			ord: nil,
+			mat: isMissingDependency,
+		},
+		// Case 004, self reference
+		{
+			nod: []Node{
+				{Nam: "a", Dep: []string{"a"}},
+			},
+			ord: nil,
+			mat: isGraphCycle,
+		},
+		// Case 005, cycle
+		{
+			nod: []Node{
+				{Nam: "a"},
+				{Nam: "b", Dep: []string{"a", "d"}},
+				{Nam: "c", Dep: []string{"b"}},
+				{Nam: "d", Dep: []string{"c"}},
+			},
+			ord: nil,
+			mat: isGraphCycle,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%03d", i), func(t *testing.T) {
+			ord, err := sort(tc.nod)
+			if tc.mat == nil && err != nil {
+				t.Fatal("expected", nil, "got", err)
+			}
+			if tc.mat != nil && !tc.mat(err) {
+				t.Fatal("expected", true, "got", false)
+			}
+
+			if dif := cmp.Diff(tc.ord, ord); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package sequence
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+)
+
+// Test_Worker_Sequence_Ensure_observer verifies that the *sequence.Worker
+// notifies the configured observer about the lifecycle of the graph and all of
+// its nodes.
+func Test_Worker_Sequence_Ensure_observer(t *testing.T) {
+	var sig chan string
+	{
+		sig = make(chan string, 10)
+	}
+
+	var obs *testObserver
+	{
+		obs = &testObserver{}
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: &policyHandler{nam: "a", sig: sig}},
+				{Nam: "b", Han: &policyHandler{nam: "b", sig: sig, err: errors.New("test error")}},
+				{Nam: "c", Han: &policyHandler{nam: "c", sig: sig}, Dep: []string{"b"}},
+			},
+			Obs: obs,
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "error", "got", nil)
+		}
+	}
+
+	// The graph hooks frame all node hooks, while the node hooks of concurrent
+	// nodes may be invoked in any order.
+
+	var eve []string
+	{
+		eve = obs.events()
+	}
+
+	{
+		exp := []string{"graph start pipeline", "graph failure pipeline"}
+		act := []string{eve[0], eve[len(eve)-1]}
+		if dif := cmp.Diff(exp, act); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+
+	{
+		eve = eve[1 : len(eve)-1]
+		slices.Sort(eve)
+	}
+
+	{
+		exp := []string{
+			"error b",
+			"skip c",
+			"start a",
+			"start b",
+			"success a",
+		}
+		if dif := cmp.Diff(exp, eve); dif != "" {
+			t.Fatalf("-expected +actual:\n%s", dif)
+		}
+	}
+}
+
+type testObserver struct {
+	eve []string
+	mut sync.Mutex
+}
+
+func (o *testObserver) add(eve string) {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	o.eve = append(o.eve, eve)
+}
+
+func (o *testObserver) events() []string {
+	o.mut.Lock()
+	defer o.mut.Unlock()
+
+	return slices.Clone(o.eve)
+}
+
+func (o *testObserver) OnStart(nam string) {
+	o.add("start " + nam)
+}
+
+func (o *testObserver) OnSuccess(nam string, _ time.Duration) {
+	o.add("success " + nam)
+}
+
+func (o *testObserver) OnError(nam string, _ time.Duration, _ error) {
+	o.add("error " + nam)
+}
+
+func (o *testObserver) OnSkip(nam string) {
+	o.add("skip " + nam)
+}
+
+func (o *testObserver) OnCoolerStart(nam string, _ time.Duration) {
+	o.add("cooler " + nam)
+}
+
+func (o *testObserver) OnGraphStart(nam string) {
+	o.add("graph start " + nam)
+}
+
+func (o *testObserver) OnGraphEnd(nam string, _ time.Duration, err error) {
+	if err != nil {
+		o.add("graph failure " + nam)
+	} else {
+		o.add("graph success " + nam)
+	}
+}
@@ -0,0 +1,27 @@
+package sequence
+
+const (
+	// PolicyContinue reports the failure of a node, but executes all of its
+	// dependents anyway, as if the failed node had succeeded.
+	PolicyContinue = "continue"
+
+	// PolicyFailFast aborts the entire graph execution once a node failed, so
+	// that no further node is executed, regardless of its dependencies. In-flight
+	// nodes are allowed to finish.
+	PolicyFailFast = "fail-fast"
+
+	// PolicySkipDependents skips all direct and indirect dependents of a failed
+	// node, while all independent branches of the graph keep being executed.
+	PolicySkipDependents = "skip-dependents"
+)
+
+const (
+	resultFailed    = "failed"
+	resultSkipped   = "skipped"
+	resultSucceeded = "succeeded"
+)
+
+// policy returns whether the given failure policy is supported.
+func policy(pol string) bool {
+	return pol == PolicyContinue || pol == PolicyFailFast || pol == PolicySkipDependents
+}
@@ -0,0 +1,147 @@
+package sequence
+
+import (
+	"time"
+
+	"github.com/0xSplits/workit/status"
+	"github.com/xh3b4sd/choreo/ticker"
+	"github.com/xh3b4sd/tracer"
+)
+
+// record is the runtime state of a single worker handler managed by this
+// worker engine. All fields are guarded by the mutex of the worker engine,
+// except for the immutable name.
+type record struct {
+	act bool
+	coo time.Duration
+	dur time.Duration
+	err error
+	nam string
+	pau bool
+	sta string
+}
+
+// Status returns the runtime snapshot of all worker handlers managed by this
+// worker engine, in the order of the configured graph.
+func (w *Worker) Status() []status.Status {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	var lis []status.Status
+	for i, x := range w.rec {
+		for j, y := range x {
+			lis = append(lis, status.Status{
+				Active:   y.act,
+				Cooler:   y.coo,
+				Duration: y.dur,
+				Engine:   "sequence",
+				Error:    y.err,
+				Index:    j,
+				Name:     y.nam,
+				Paused:   y.pau,
+				Stage:    i,
+				State:    y.sta,
+			})
+		}
+	}
+
+	return lis
+}
+
+// Pause disables the worker handler of the given node name until it is resumed
+// again. Paused worker handlers are skipped during graph executions, like
+// inactive worker handlers. In-flight executions are not affected.
+func (w *Worker) Pause(nam string) error {
+	err := w.update(nam, func(r *record) { r.pau = true })
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker paused handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// Resume enables the paused worker handler of the given node name again,
+// starting with the next graph execution.
+func (w *Worker) Resume(nam string) error {
+	err := w.update(nam, func(r *record) { r.pau = false })
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	w.log.Log(
+		"level", "info",
+		"message", "worker resumed handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// Trigger interrupts the cooler of Worker.Daemon, so that the graph containing
+// the worker handler of the given node name gets executed right away. Worker
+// handlers cannot be executed in isolation, because they may depend on the
+// worker handlers of earlier stages. Multiple triggers received during a graph
+// execution are coalesced into a single graph execution. Trigger returns
+// status.DaemonDisabledError if no cooler duration was configured, because
+// Worker.Daemon does not execute the graph in that case.
+func (w *Worker) Trigger(nam string) error {
+	err := w.update(nam, func(r *record) {})
+	if err != nil {
+		return tracer.Mask(err)
+	}
+
+	if _, typ := w.tic.(ticker.Fake); typ {
+		return tracer.Mask(status.DaemonDisabledError, tracer.Context{Key: "handler", Value: nam})
+	}
+
+	select {
+	case w.wak <- struct{}{}:
+	default:
+	}
+
+	w.log.Log(
+		"level", "debug",
+		"message", "worker triggered handler",
+		"handler", nam,
+	)
+
+	return nil
+}
+
+// update applies the given function to the worker handler of the given node
+// name, and returns status.NotFoundError if no such worker handler exists.
+func (w *Worker) update(nam string, fnc func(r *record)) error {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	var fou bool
+	for _, x := range w.rec {
+		for _, y := range x {
+			if y.nam == nam {
+				fnc(y)
+				fou = true
+			}
+		}
+	}
+
+	if !fou {
+		return tracer.Mask(status.NotFoundError, tracer.Context{Key: "handler", Value: nam})
+	}
+
+	return nil
+}
+
+// state applies the given function to the given record while holding the
+// mutex of this worker engine.
+func (w *Worker) state(rec *record, fnc func(r *record)) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+
+	fnc(rec)
+}
@@ -0,0 +1,32 @@
+package sequence
+
+import (
+	"context"
+
+	"github.com/xh3b4sd/tracer"
+)
+
+// Stop signals Worker.Daemon to stop scheduling new graph executions and blocks
+// until Worker.Daemon returned, or until the given context expired. Note that
+// Stop must only be called after Worker.Daemon got started, because otherwise
+// Stop blocks until the given context expires.
+func (w *Worker) Stop(ctx context.Context) error {
+	{
+		w.stop()
+	}
+
+	select {
+	case <-w.don:
+		return nil
+	case <-ctx.Done():
+		return tracer.Mask(ctx.Err())
+	}
+}
+
+// stop closes the internal stop channel exactly once, so that stop may be
+// called safely by Worker.Daemon and Worker.Stop concurrently.
+func (w *Worker) stop() {
+	w.onc.Do(func() {
+		close(w.stp)
+	})
+}
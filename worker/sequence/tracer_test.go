@@ -0,0 +1,185 @@
+package sequence
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/0xSplits/otelgo/recorder"
+	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/registry"
+	"github.com/google/go-cmp/cmp"
+	"github.com/xh3b4sd/logger"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Test_Worker_Sequence_Ensure_graph_tracer verifies that the *sequence.Worker
+// traces every graph execution within a parent span, with a child span for
+// every executed stage, which in turn is the parent of the spans of all worker
+// handlers of the stage.
+func Test_Worker_Sequence_Ensure_graph_tracer(t *testing.T) {
+	var exp *tracetest.InMemoryExporter
+	{
+		exp = tracetest.NewInMemoryExporter()
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "a"})},
+				{Nam: "b", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return errors.New("test error") }, Nam: "b"}), Dep: []string{"a"}},
+				{Nam: "c", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return nil }, Nam: "c"}), Dep: []string{"b"}},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+				Tra: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if err == nil {
+			t.Fatal("expected", "test error", "got", nil)
+		}
+	}
+
+	var spn map[string]tracetest.SpanStub
+	{
+		spn = map[string]tracetest.SpanStub{}
+	}
+
+	for _, x := range exp.GetSpans() {
+		spn[x.Name] = x
+	}
+
+	// Stage 2 is never executed, because node b fails in stage 1.
+
+	if dif := cmp.Diff(5, len(spn)); dif != "" {
+		t.Fatalf("-expected +actual:\n%s", dif)
+	}
+
+	testCases := []struct {
+		nam string
+		par string
+		sta codes.Code
+	}{
+		// Case 000
+		{nam: "pipeline", par: "", sta: codes.Error},
+		// Case 001
+		{nam: "stage 0", par: "pipeline", sta: codes.Unset},
+		// Case 002
+		{nam: "stage 1", par: "pipeline", sta: codes.Error},
+		// Case 003
+		{nam: "a", par: "stage 0", sta: codes.Unset},
+		// Case 004
+		{nam: "b", par: "stage 1", sta: codes.Error},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.nam, func(t *testing.T) {
+			x, e := spn[tc.nam]
+			if !e {
+				t.Fatal("expected", tc.nam, "got", nil)
+			}
+
+			if dif := cmp.Diff(tc.sta, x.Status.Code); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if tc.par == "" {
+				if x.Parent.IsValid() {
+					t.Fatal("expected", "root span", "got", x.Parent.SpanID())
+				}
+			} else {
+				if dif := cmp.Diff(spn[tc.par].SpanContext.SpanID(), x.Parent.SpanID()); dif != "" {
+					t.Fatalf("-expected +actual:\n%s", dif)
+				}
+			}
+
+			if dif := cmp.Diff(spn["pipeline"].SpanContext.TraceID(), x.SpanContext.TraceID()); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
+
+// Test_Worker_Sequence_Ensure_graph_tracer_filter verifies that filtered errors
+// are recorded on the spans of the graph and its stages, without marking them
+// as failed, just like the spans of the worker handlers.
+func Test_Worker_Sequence_Ensure_graph_tracer_filter(t *testing.T) {
+	var exp *tracetest.InMemoryExporter
+	{
+		exp = tracetest.NewInMemoryExporter()
+	}
+
+	var fil error
+	{
+		fil = errors.New("filtered error")
+	}
+
+	var wor *Worker
+	{
+		wor = New(Config{
+			Log: logger.Fake(),
+			Nam: "pipeline",
+			Nod: []Node{
+				{Nam: "a", Han: handler.NewFunc(handler.FuncConfig{Fun: func() error { return fil }, Nam: "a"})},
+			},
+			Reg: registry.New(registry.Config{
+				Env: "testing",
+				Fil: func(err error) bool { return errors.Is(err, fil) },
+				Log: logger.Fake(),
+				Met: recorder.NewMeter(recorder.MeterConfig{
+					Env: "testing",
+					Sco: "workit",
+					Ver: "v0.1.0",
+				}),
+				Tra: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)),
+			}),
+		})
+	}
+
+	{
+		err := wor.Ensure()
+		if !errors.Is(err, fil) {
+			t.Fatal("expected", fil, "got", err)
+		}
+	}
+
+	var spn map[string]tracetest.SpanStub
+	{
+		spn = map[string]tracetest.SpanStub{}
+	}
+
+	for _, x := range exp.GetSpans() {
+		spn[x.Name] = x
+	}
+
+	for _, x := range []string{"pipeline", "stage 0", "a"} {
+		t.Run(x, func(t *testing.T) {
+			s, e := spn[x]
+			if !e {
+				t.Fatal("expected", x, "got", nil)
+			}
+
+			if dif := cmp.Diff(codes.Unset, s.Status.Code); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+
+			if dif := cmp.Diff(1, len(s.Events)); dif != "" {
+				t.Fatalf("-expected +actual:\n%s", dif)
+			}
+		})
+	}
+}
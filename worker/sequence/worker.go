@@ -2,10 +2,21 @@ package sequence
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
+	otelreg "github.com/0xSplits/otelgo/registry"
+	"github.com/0xSplits/workit/executor"
 	"github.com/0xSplits/workit/handler"
+	"github.com/0xSplits/workit/health"
+	"github.com/0xSplits/workit/leader"
+	"github.com/0xSplits/workit/locker"
+	"github.com/0xSplits/workit/observer"
 	"github.com/0xSplits/workit/registry"
+	"github.com/0xSplits/workit/semaphore"
+	"github.com/0xSplits/workit/status"
 	"github.com/xh3b4sd/choreo/ticker"
 	"github.com/xh3b4sd/logger"
 	"github.com/xh3b4sd/tracer"
@@ -20,37 +31,161 @@ type Config struct {
 	// be used on demand even without specified cooler duration.
 	Coo time.Duration
 
+	// Gra is the optional grace period granted to an in-flight graph execution
+	// once Worker.Daemon got stopped. The execution context provided to worker
+	// handlers implementing handler.EnsureContext is cancelled after this grace
+	// period expired. Defaults to 20 seconds, which fits into the default
+	// termination grace period of Kubernetes pods.
+	Gra time.Duration
+
 	// Han is the list of worker handlers implementing the actual business logic
-	// as a directed acyclic graph. The worker handlers configured here may be
-	// wrapped in administrative handler implementations to e.g. instrument
-	// handler execution latency and handler error rates. All worker handlers
-	// provided here will be executed sequentially within the same failure domain.
+	// as ordered stages. All worker handlers of a stage are executed
+	// concurrently, once all worker handlers of the previous stage completed.
+	// Han is a convenience that compiles down to the directed acyclic graph of
+	// Nod, where every node is named after its worker handler, and depends on
+	// all nodes of the previous stage. Either Han or Nod must be provided.
 	Han [][]handler.Ensure
 
+	// Hea is the optional health tracker recording the execution state of all
+	// worker handlers, so that their health can be exposed via HTTP. The cooler
+	// of this worker engine is the expected cooler of all worker handlers.
+	Hea *health.Health
+
+	// Loc is the optional locker used for leader election across multiple
+	// replicas. If provided, the directed acyclic graph is only executed while
+	// this worker engine holds the lease of the graph, keyed by Nam. Worker
+	// handlers implementing handler.Locker are additionally only executed while
+	// holding their own lease, keyed by handler name. No leader election is
+	// applied by default.
+	Loc locker.Interface
+
 	// Log is a standard logger interface to forward structured log messages to
 	// any output interface e.g. stdout.
 	Log logger.Interface
 
+	// Max is the optional maximum number of nodes executed concurrently within
+	// the same stage of the graph, where the stage of a node is the length of its
+	// longest dependency path. Nodes exceeding this limit wait for a running node
+	// of the same stage to finish before being executed. No limit is applied by
+	// default.
+	Max int
+
+	// Mid is the optional list of middlewares wrapping every worker handler of
+	// this worker engine, in the given order. These middlewares are applied
+	// inside of the middlewares configured on Reg, so that e.g. registry wide
+	// middlewares observe every execution first.
+	Mid []handler.Middleware
+
+	// Nam is the optional name of the directed acyclic graph, which is used as
+	// lease key for leader election, and as pipeline label of all graph metrics,
+	// so that multiple sequence worker engines can coexist within the same
+	// process. Defaults to "sequence".
+	Nam string
+
+	// Nod is the list of worker handlers implementing the actual business logic
+	// as a directed acyclic graph. Every node declares the nodes it depends on by
+	// name, and is executed as soon as all of its dependencies completed. The
+	// worker handlers configured here may be wrapped in administrative handler
+	// implementations to e.g. instrument handler execution latency and handler
+	// error rates. All worker handlers provided here will be executed within the
+	// same failure domain. Either Han or Nod must be provided.
+	Nod []Node
+
+	// Obs is the optional observer notified about the lifecycle of all worker
+	// handler executions, e.g. to implement alerting or audit logs. All hooks are
+	// invoked synchronously. No observer is notified by default.
+	Obs observer.Interface
+
+	// Pan is the optional flag to disable the recovery of panicking worker
+	// handlers. By default, any panic is converted into an error that is logged
+	// and instrumented, so that the worker engine keeps running. Setting Pan to
+	// true crashes the process instead.
+	Pan bool
+
+	// Pol is the optional default failure policy applied to all nodes that do
+	// not define their own failure policy. Defaults to PolicySkipDependents,
+	// which skips all dependents of failed nodes, so that stages configured via
+	// Han are aborted after the first failed stage.
+	Pol string
+
 	// Reg is the metrics interface used to wrap the internally managed handlers
 	// for instrumentation purposes. The metrics handlers created by this registry
 	// will record all worker handler execution metrics.
 	Reg *registry.Registry
+
+	// Ren is the optional interval at which leases are renewed while the work
+	// guarded by them is still executing. Executions are cancelled once their
+	// lease cannot be renewed anymore. The interval must be shorter than the
+	// lease duration of the configured lockers. Defaults to 10 seconds.
+	Ren time.Duration
+
+	// Sem is the optional semaphore bounding the number of concurrent executions
+	// of all worker handlers sharing the same resource group. Sem must be
+	// provided if any worker handler implements handler.Group, and must contain
+	// all resource groups of those worker handlers.
+	Sem *semaphore.Semaphore
+
+	// Tim is the optional default timeout applied to all worker handler
+	// executions, unless the underlying worker handler implements the
+	// handler.Timeout interface. Executions exceeding their timeout are abandoned
+	// and recorded as timeout failures. No timeout is applied by default.
+	Tim time.Duration
 }
 
 type Worker struct {
-	han [][]handler.Interface
+	coo time.Duration
+	don chan struct{}
+	exe *executor.Executor
+	gra time.Duration
+	hea *health.Health
+	lea *leader.Leader
+	loc locker.Interface
 	log logger.Interface
+	met otelreg.Interface
+	mut sync.Mutex
+	nam string
+	nod []*node
+	obs observer.Interface
+	onc sync.Once
+	rec [][]*record
 	reg *registry.Registry
+	sem *semaphore.Semaphore
+	stp chan struct{}
 	tic ticker.Interface
+	wak chan struct{}
 }
 
 func New(c Config) *Worker {
-	if len(c.Han) == 0 {
-		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han must not be empty", c)))
+	if c.Gra == 0 {
+		c.Gra = 20 * time.Second
+	}
+	if len(c.Han) == 0 && len(c.Nod) == 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han or %T.Nod must not be empty", c, c)))
+	}
+	if len(c.Han) != 0 && len(c.Nod) != 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Han and %T.Nod must not be provided together", c, c)))
+	}
+	if c.Hea == nil {
+		c.Hea = health.New(health.Config{})
 	}
 	if c.Log == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Log must not be empty", c)))
 	}
+	if c.Max < 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Max must not be negative", c)))
+	}
+	if c.Nam == "" {
+		c.Nam = "sequence"
+	}
+	if c.Pol == "" {
+		c.Pol = PolicySkipDependents
+	}
+	if !policy(c.Pol) {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Pol must be a supported failure policy", c)))
+	}
+	if c.Obs == nil {
+		c.Obs = observer.Fake{}
+	}
 	if c.Reg == nil {
 		tracer.Panic(tracer.Mask(fmt.Errorf("%T.Reg must not be empty", c)))
 	}
@@ -70,22 +205,208 @@ func New(c Config) *Worker {
 		}
 	}
 
-	// Wrap the list of injected worker handlers into their own metrics handler,
-	// so that we can instrument the underlying handler interfaces.
+	// Compile the convenience stages into the directed acyclic graph, so that
+	// all worker handlers are executed the same way.
+
+	var fie string
+	if len(c.Han) != 0 {
+		fie = "Han"
+		c.Nod = compile(c.Han)
+	} else {
+		fie = "Nod"
+	}
+
+	// Copy the given nodes before applying any defaults, so that the caller's
+	// nodes remain untouched, e.g. when reusing the same nodes for multiple
+	// worker engines concurrently.
+
+	{
+		c.Nod = slices.Clone(c.Nod)
+	}
+
+	// Verify early on that no node handler is ever nil, and that all node names
+	// are unique.
+
+	var dup []string
+	var ind map[string]bool
+	{
+		ind = map[string]bool{}
+	}
+
+	for i := range c.Nod {
+		if c.Nod[i].Han == nil {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Nod[%d].Han must not be empty", c, i)))
+		}
+		if c.Nod[i].Nam == "" {
+			c.Nod[i].Nam = handler.Name(c.Nod[i].Han)
+		}
+		if c.Nod[i].Pol == "" {
+			c.Nod[i].Pol = c.Pol
+		}
+		if !policy(c.Nod[i].Pol) {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Nod[%d].Pol must be a supported failure policy", c, i)))
+		}
+		if ind[c.Nod[i].Nam] {
+			dup = append(dup, c.Nod[i].Nam)
+		}
+
+		{
+			ind[c.Nod[i].Nam] = true
+		}
+	}
+
+	if len(dup) != 0 {
+		tracer.Panic(tracer.Mask(fmt.Errorf("%T.%s must not contain duplicate names, found %s", c, fie, strings.Join(dup, ", "))))
+	}
+
+	// Sort the graph topologically, which verifies that all dependencies exist
+	// and that the graph is acyclic. Wrap the injected worker handlers into their
+	// own metrics handler, so that we can instrument the underlying handler
+	// interfaces.
+
+	var nod []*node
+	var pos map[string]int
+	{
+		pos = map[string]int{}
+	}
+
+	var ord []int
+	{
+		var err error
+
+		ord, err = sort(c.Nod)
+		if err != nil {
+			tracer.Panic(tracer.Mask(err))
+		}
+	}
+
+	for _, i := range ord {
+		var x Node
+		{
+			x = c.Nod[i]
+		}
+
+		var dep []int
+		for _, y := range x.Dep {
+			dep = append(dep, pos[y])
+		}
+
+		var han handler.Interface
+		{
+			han = c.Reg.New(x.Han, c.Mid...)
+		}
+
+		{
+			pos[x.Nam] = len(nod)
+		}
+
+		nod = append(nod, &node{
+			dep: dep,
+			han: han,
+			nam: x.Nam,
+			pol: x.Pol,
+			rec: &record{
+				act: true,
+				nam: x.Nam,
+				sta: status.StateIdle,
+			},
+		})
+	}
+
+	// Verify early on that all handler names are unique, because handler names
+	// identify worker handlers for instrumentation, health reporting and leader
+	// election. Note that node names may differ for worker handlers of the same
+	// name.
+
+	{
+		var key []string
+		for _, x := range nod {
+			key = append(key, handler.Name(x.han.Unwrap()))
+		}
+
+		dup := handler.Duplicates(key)
+		if len(dup) != 0 {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.%s must not contain duplicate handler names, found %s", c, fie, strings.Join(dup, ", "))))
+		}
+	}
+
+	// Verify early on that the resource groups of all worker handlers exist.
 
-	var han [][]handler.Interface
-	for _, x := range c.Han {
-		var row []handler.Interface
+	for _, x := range nod {
+		grp := x.han.Group()
+		if grp == "" {
+			continue
+		}
 
-		for _, y := range x {
-			row = append(row, c.Reg.New(y))
+		if c.Sem == nil || !c.Sem.Exists(grp) {
+			tracer.Panic(tracer.Mask(fmt.Errorf("%T.Sem must contain resource group %s of handler %s", c, grp, x.nam)))
+		}
+	}
+
+	// Group the runtime state of all worker handlers by their depth within the
+	// graph, so that the graph position can be reported. All nodes of the same
+	// depth share the same concurrency limit, if any.
+
+	var lim []chan struct{}
+	var rec [][]*record
+	for i, x := range depth(nod) {
+		for len(rec) <= x {
+			lim = append(lim, nil)
+			rec = append(rec, nil)
+		}
+
+		if c.Max > 0 && lim[x] == nil {
+			lim[x] = make(chan struct{}, c.Max)
 		}
 
 		{
-			han = append(han, row)
+			nod[i].lim = lim[x]
+			nod[i].stg = x
+			rec[x] = append(rec[x], nod[i].rec)
 		}
 	}
 
+	// Whitelist the lease keys of the graph and all of its worker handlers, so
+	// that lease acquisition can be instrumented for all of them. Also register
+	// all worker handlers for health reporting.
+
+	var key []string
+	{
+		key = append(key, c.Nam)
+	}
+
+	for _, x := range nod {
+		key = append(key, handler.Name(x.han.Unwrap()))
+		c.Hea.Register(x.rec.nam)
+	}
+
+	var exe *executor.Executor
+	{
+		exe = executor.New(executor.Config{
+			Hea: c.Hea,
+			Pan: c.Pan,
+			Reg: c.Reg,
+			Tim: c.Tim,
+		})
+	}
+
+	var lea *leader.Leader
+	{
+		lea = leader.New(leader.Config{
+			Key: key,
+			Log: c.Log,
+			Reg: c.Reg,
+			Ren: c.Ren,
+		})
+	}
+
+	// Whitelist the graph metrics of this pipeline for all stages of the graph.
+
+	var met otelreg.Interface
+	{
+		met = metrics(c.Reg, c.Nam, len(rec))
+	}
+
 	// Allocate a real or fake ticker based on the injected cooler duration, so
 	// that Worker.Ensure may be used without the need for Worker.Daemon.
 
@@ -97,9 +418,23 @@ func New(c Config) *Worker {
 	}
 
 	return &Worker{
-		han: han,
+		coo: c.Coo,
+		don: make(chan struct{}),
+		exe: exe,
+		gra: c.Gra,
+		hea: c.Hea,
+		lea: lea,
+		loc: c.Loc,
 		log: c.Log,
+		met: met,
+		nam: c.Nam,
+		nod: nod,
+		obs: c.Obs,
+		rec: rec,
 		reg: c.Reg,
+		sem: c.Sem,
+		stp: make(chan struct{}),
 		tic: tic,
+		wak: make(chan struct{}, 1),
 	}
 }